@@ -18,10 +18,47 @@
 // ```
 package deque
 
+import (
+	"iter"
+	"slices"
+)
+
 type Deque[T any] struct {
 	data  []T
 	first uint
 	size  uint
+
+	minCap     uint
+	autoShrink bool
+	limit      uint
+	overflow   OverflowPolicy
+	onEvict    func(T)
+}
+
+// OverflowPolicy decides what happens when elements are pushed to a full bounded deque.
+type OverflowPolicy int
+
+const (
+	// The deque has no fixed capacity and grows to make room for new elements.
+	Unbounded OverflowPolicy = iota
+	// The deque evicts elements from the opposite end to make room for new elements,
+	// like a ring buffer.
+	Overwrite
+	// The deque refuses to add elements that don't fit. TryPushBack, TryPushFront and TryInsert
+	// return false, while PushBack, PushFront and Insert panic.
+	Reject
+)
+
+// Options configure a deque created by NewWithOptions.
+type Options struct {
+	// Capacity is the initial capacity of the deque. An automatically shrinking deque never
+	// shrinks below it and a bounded deque never holds more elements.
+	Capacity uint
+	// AutoShrink halves the capacity of the deque whenever removing elements leaves it at most
+	// a quarter full.
+	AutoShrink bool
+	// Overflow decides what happens when the deque is full. The default is Unbounded.
+	Overflow OverflowPolicy
 }
 
 // New creates a new deque.
@@ -29,6 +66,23 @@ func New[T any](capacity uint) Deque[T] {
 	return Deque[T]{data: make([]T, capacity)}
 }
 
+// NewWithOptions creates a new deque configured by opts.
+func NewWithOptions[T any](opts Options) Deque[T] {
+	return Deque[T]{
+		data:       make([]T, opts.Capacity),
+		minCap:     opts.Capacity,
+		autoShrink: opts.AutoShrink,
+		limit:      opts.Capacity,
+		overflow:   opts.Overflow,
+	}
+}
+
+// OnEvict sets a callback that is called with every element evicted by the Overwrite policy.
+// A nil callback removes the previous one.
+func (d *Deque[T]) OnEvict(fn func(elem T)) {
+	d.onEvict = fn
+}
+
 // Len returns the number of elements in the deque.
 func (d *Deque[T]) Len() uint {
 	return d.size
@@ -39,30 +93,65 @@ func (d *Deque[T]) Cap() uint {
 	return uint(len(d.data))
 }
 
-// PushBack adds an element to the back of the deque.
+// PushBack adds elements to the back of the deque. A full bounded deque evicts elements from
+// the front to make room. With the Reject policy it panics if the elements don't fit, use
+// TryPushBack to be told instead.
 func (d *Deque[T]) PushBack(elems ...T) {
+	if !d.TryPushBack(elems...) {
+		panic("deque: PushBack() called on full deque")
+	}
+}
+
+// PushFront adds elements to the front of the deque, so the last one ends up in front. A full
+// bounded deque evicts elements from the back to make room. With the Reject policy it panics
+// if the elements don't fit, use TryPushFront to be told instead.
+func (d *Deque[T]) PushFront(elems ...T) {
+	if !d.TryPushFront(elems...) {
+		panic("deque: PushFront() called on full deque")
+	}
+}
+
+// TryPushBack is like PushBack, but with the Reject policy a full deque adds nothing and
+// returns false.
+func (d *Deque[T]) TryPushBack(elems ...T) bool {
+	elems, ok := d.makeRoom(elems, true)
+	if !ok {
+		return false
+	}
 	n := uint(len(elems))
 	if d.Len()+n > d.Cap() {
 		d.ensureCapacity(n)
 	}
-	for i, elem := range elems {
-		d.data[(d.first+d.size+uint(i))%d.Cap()] = elem
-	}
+	a, b := d.segments(d.size, n)
+	copy(b, elems[copy(a, elems):])
 	d.size += n
+	return true
 }
 
-// PushFront adds an element to the front of the deque.
-func (d *Deque[T]) PushFront(elems ...T) {
+// TryPushFront is like PushFront, but with the Reject policy a full deque adds nothing and
+// returns false.
+func (d *Deque[T]) TryPushFront(elems ...T) bool {
+	elems, ok := d.makeRoom(elems, false)
+	if !ok {
+		return false
+	}
 	n := uint(len(elems))
+	if n == 0 {
+		return true
+	}
 	if d.Len()+n > d.Cap() {
 		d.ensureCapacity(n)
 	}
 	c := d.Cap()
 	d.first = (d.first + c - n) % c
-	for i, elem := range elems {
-		d.data[(d.first+n-1-uint(i))%c] = elem
-	}
+	// The elements are stored in reverse, the last ones go into the first part.
+	a, b := d.segments(0, n)
+	copy(a, elems[n-uint(len(a)):])
+	copy(b, elems)
+	slices.Reverse(a)
+	slices.Reverse(b)
 	d.size += n
+	return true
 }
 
 // PopBack removes and returns the element at the back of the deque.
@@ -74,6 +163,7 @@ func (d *Deque[T]) PopBack() T {
 	idx := (d.first + d.size) % d.Cap()
 	elem := d.data[idx]
 	d.data[idx] = d.zeroElement()
+	d.maybeShrink()
 	return elem
 }
 
@@ -86,6 +176,7 @@ func (d *Deque[T]) PopFront() T {
 	d.data[d.first] = d.zeroElement()
 	d.first = (d.first + 1) % d.Cap()
 	d.size--
+	d.maybeShrink()
 	return elem
 }
 
@@ -110,33 +201,262 @@ func (d *Deque[T]) PopNBack(n uint) []T {
 	if n > d.Len() {
 		panic("deque: PopNBack() called with n > Len()")
 	}
-	elems := make([]T, 0, n)
-	for i := d.first + d.size - 1; i >= d.first+d.size-n; i-- {
-		elems = append(elems, d.data[i%d.Cap()])
-		d.data[i%d.Cap()] = d.zeroElement()
-	}
+	a, b := d.segments(d.size-n, n)
+	elems := make([]T, n)
+	copy(elems[copy(elems, a):], b)
+	clear(a)
+	clear(b)
+	slices.Reverse(elems)
 	d.size -= n
+	d.maybeShrink()
 	return elems
 }
 
-// PopNFront removes and returns the last n elements from the deque.
+// PopNFront removes and returns the first n elements from the deque.
 func (d *Deque[T]) PopNFront(n uint) []T {
 	if n > d.Len() {
 		panic("deque: PopNFront() called with n > Len()")
 	}
-	c := d.Cap()
-	elems := make([]T, 0, n)
-	for i := d.first; i < d.first+n; i++ {
-		elems = append(elems, d.data[i%c])
-		d.data[i%c] = d.zeroElement()
+	if n == 0 {
+		return []T{}
 	}
-	d.first = (d.first + n) % c
+	a, b := d.segments(0, n)
+	elems := make([]T, n)
+	copy(elems[copy(elems, a):], b)
+	clear(a)
+	clear(b)
+	d.first = d.index(n)
 	d.size -= n
+	d.maybeShrink()
 	return elems
 }
 
+// TryPopBack removes and returns the element at the back of the deque if there is one.
+func (d *Deque[T]) TryPopBack() (elem T, ok bool) {
+	if d.Len() == 0 {
+		return
+	}
+	return d.PopBack(), true
+}
+
+// TryPopFront removes and returns the element at the front of the deque if there is one.
+func (d *Deque[T]) TryPopFront() (elem T, ok bool) {
+	if d.Len() == 0 {
+		return
+	}
+	return d.PopFront(), true
+}
+
+// PeekBack returns the element at the back of the deque if there is one.
+func (d *Deque[T]) PeekBack() (elem T, ok bool) {
+	if d.Len() == 0 {
+		return
+	}
+	return d.Back(), true
+}
+
+// PeekFront returns the element at the front of the deque if there is one.
+func (d *Deque[T]) PeekFront() (elem T, ok bool) {
+	if d.Len() == 0 {
+		return
+	}
+	return d.Front(), true
+}
+
+// TryPopNBack removes and returns at most n elements from the back of the deque.
+func (d *Deque[T]) TryPopNBack(n uint) []T {
+	return d.PopNBack(min(n, d.Len()))
+}
+
+// TryPopNFront removes and returns at most n elements from the front of the deque.
+func (d *Deque[T]) TryPopNFront(n uint) []T {
+	return d.PopNFront(min(n, d.Len()))
+}
+
+// Slices returns the elements of the deque, from front to back, as two contiguous parts of the
+// underlying ring buffer. The second part is empty unless the elements wrap around the end of the
+// buffer. The slices share memory with the deque and are only valid until it is modified.
+func (d *Deque[T]) Slices() (a, b []T) {
+	return d.segments(0, d.size)
+}
+
+// AppendTo appends the elements of the deque, from front to back, to dst and returns the extended slice.
+func (d *Deque[T]) AppendTo(dst []T) []T {
+	a, b := d.Slices()
+	return append(append(dst, a...), b...)
+}
+
+// CopyTo copies elements of the deque, from front to back, into dst. It returns the number of
+// elements copied, which is the minimum of len(dst) and Len().
+func (d *Deque[T]) CopyTo(dst []T) int {
+	a, b := d.Slices()
+	n := copy(dst, a)
+	return n + copy(dst[n:], b)
+}
+
+// At returns the i-th element from the front of the deque.
+func (d *Deque[T]) At(i uint) T {
+	if i >= d.Len() {
+		panic("deque: At() called with i >= Len()")
+	}
+	return d.data[d.index(i)]
+}
+
+// Set replaces the i-th element from the front of the deque with elem.
+func (d *Deque[T]) Set(i uint, elem T) {
+	if i >= d.Len() {
+		panic("deque: Set() called with i >= Len()")
+	}
+	d.data[d.index(i)] = elem
+}
+
+// Insert puts elem at position i, counted from the front of the deque. Elements on the shorter
+// side of i are shifted by one to make room. Insert(Len(), elem) is the same as PushBack(elem),
+// including how a full bounded deque makes room. With the Reject policy it panics if elem doesn't
+// fit, use TryInsert to be told instead.
+func (d *Deque[T]) Insert(i uint, elem T) {
+	if !d.TryInsert(i, elem) {
+		panic("deque: Insert() called on full deque")
+	}
+}
+
+// TryInsert is like Insert, but with the Reject policy a full deque doesn't insert elem and
+// returns false.
+func (d *Deque[T]) TryInsert(i uint, elem T) bool {
+	if i > d.Len() {
+		panic("deque: Insert() called with i > Len()")
+	}
+	if d.overflow != Unbounded && d.size >= d.limit {
+		if d.overflow == Reject {
+			return false
+		}
+		if i == 0 {
+			// The new element would be the first one to go.
+			d.evicted(elem)
+			return true
+		}
+		d.evictFront(1)
+		i--
+	}
+	if d.Len()+1 > d.Cap() {
+		d.ensureCapacity(1)
+	}
+	if i < d.size/2 {
+		// Shift the elements before i one place towards the front.
+		c := d.Cap()
+		d.first = (d.first + c - 1) % c
+		for j := uint(0); j < i; j++ {
+			d.data[d.index(j)] = d.data[d.index(j+1)]
+		}
+	} else {
+		// Shift the elements from i onwards one place towards the back.
+		for j := d.size; j > i; j-- {
+			d.data[d.index(j)] = d.data[d.index(j-1)]
+		}
+	}
+	d.data[d.index(i)] = elem
+	d.size++
+	return true
+}
+
+// RemoveAt removes and returns the i-th element from the front of the deque. Elements on the
+// shorter side of i are shifted by one to close the gap.
+func (d *Deque[T]) RemoveAt(i uint) T {
+	if i >= d.Len() {
+		panic("deque: RemoveAt() called with i >= Len()")
+	}
+	elem := d.data[d.index(i)]
+	if i < d.size/2 {
+		// Shift the elements before i one place towards the back.
+		for j := i; j > 0; j-- {
+			d.data[d.index(j)] = d.data[d.index(j-1)]
+		}
+		d.data[d.first] = d.zeroElement()
+		d.first = (d.first + 1) % d.Cap()
+	} else {
+		// Shift the elements after i one place towards the front.
+		for j := i; j+1 < d.size; j++ {
+			d.data[d.index(j)] = d.data[d.index(j+1)]
+		}
+		d.data[d.index(d.size-1)] = d.zeroElement()
+	}
+	d.size--
+	d.maybeShrink()
+	return elem
+}
+
+// Clear removes all elements from the deque. The capacity is kept unless the deque shrinks automatically.
+func (d *Deque[T]) Clear() {
+	clear(d.data)
+	d.first = 0
+	d.size = 0
+	d.maybeShrink()
+}
+
+// Grow increases the capacity of the deque, if necessary, to guarantee space for another n elements.
+// A bounded deque never grows beyond its fixed capacity.
+func (d *Deque[T]) Grow(n uint) {
+	if d.overflow != Unbounded {
+		n = min(n, d.limit-d.size)
+	}
+	if d.Len()+n > d.Cap() {
+		d.ensureCapacity(n)
+	}
+}
+
+// ShrinkToFit reduces the capacity of the deque to its length.
+func (d *Deque[T]) ShrinkToFit() {
+	if d.Cap() > d.Len() {
+		d.resize(d.Len())
+	}
+}
+
+// All returns an iterator over index-value pairs of the deque, from front to back.
+// The deque must not be modified during the iteration.
+func (d *Deque[T]) All() iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		for i := uint(0); i < d.size; i++ {
+			if !yield(i, d.data[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs of the deque, from back to front.
+// The deque must not be modified during the iteration.
+func (d *Deque[T]) Backward() iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		for i := d.size; i > 0; i-- {
+			if !yield(i-1, d.data[d.index(i-1)]) {
+				return
+			}
+		}
+	}
+}
+
+// PopAllFront returns an iterator that removes and yields elements from the front of the deque
+// until it is empty. Elements that were not yet yielded when the iteration stops stay in the deque.
+func (d *Deque[T]) PopAllFront() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for d.size > 0 {
+			if !yield(d.PopFront()) {
+				return
+			}
+		}
+	}
+}
+
 // checkCapacity will double the capacity of the deque until there is rome for n additional elements.
 func (d *Deque[T]) ensureCapacity(n uint) {
+	if d.overflow != Unbounded {
+		c := max(d.Cap(), 1)
+		for d.Len()+n > c {
+			c *= 2
+		}
+		d.resize(min(c, d.limit))
+		return
+	}
 	if d.Cap() == 0 {
 		d.data = make([]T, n)
 		return
@@ -150,4 +470,98 @@ func (d *Deque[T]) ensureCapacity(n uint) {
 	}
 }
 
+// makeRoom evicts elements from a full bounded deque until elems fit, from the front if fromFront is set
+// and from the back otherwise. It returns the elems that are left to push, dropping those that would be
+// evicted right away, or false if the deque rejects them.
+func (d *Deque[T]) makeRoom(elems []T, fromFront bool) ([]T, bool) {
+	n := uint(len(elems))
+	if d.overflow == Unbounded || d.size+n <= d.limit {
+		return elems, true
+	}
+	if d.overflow == Reject {
+		return nil, false
+	}
+	if fromFront {
+		d.evictFront(min(d.size+n-d.limit, d.size))
+	} else {
+		d.evictBack(min(d.size+n-d.limit, d.size))
+	}
+	if n > d.limit {
+		for _, elem := range elems[:n-d.limit] {
+			d.evicted(elem)
+		}
+		elems = elems[n-d.limit:]
+	}
+	return elems, true
+}
+
+// evictFront removes n elements from the front of the deque and reports them as evicted.
+func (d *Deque[T]) evictFront(n uint) {
+	for range n {
+		elem := d.data[d.first]
+		d.data[d.first] = d.zeroElement()
+		d.first = (d.first + 1) % d.Cap()
+		d.size--
+		d.evicted(elem)
+	}
+}
+
+// evictBack removes n elements from the back of the deque and reports them as evicted.
+func (d *Deque[T]) evictBack(n uint) {
+	for range n {
+		d.size--
+		idx := d.index(d.size)
+		elem := d.data[idx]
+		d.data[idx] = d.zeroElement()
+		d.evicted(elem)
+	}
+}
+
+func (d *Deque[T]) evicted(elem T) {
+	if d.onEvict != nil {
+		d.onEvict(elem)
+	}
+}
+
+// maybeShrink halves the capacity of an automatically shrinking deque while it is at most a quarter full.
+func (d *Deque[T]) maybeShrink() {
+	if !d.autoShrink {
+		return
+	}
+	c := d.Cap()
+	for c > d.minCap && d.size <= c/4 {
+		c = max(c/2, d.minCap)
+	}
+	if c < d.Cap() {
+		d.resize(c)
+	}
+}
+
+// resize moves the elements into a new buffer of the given capacity, starting at index 0.
+func (d *Deque[T]) resize(capacity uint) {
+	data := make([]T, capacity)
+	d.CopyTo(data)
+	d.data = data
+	d.first = 0
+}
+
+// segments returns the n elements starting with the i-th element from the front, as one or two
+// contiguous parts of data. The i+n elements from the front have to fit within Cap().
+func (d *Deque[T]) segments(i, n uint) (a, b []T) {
+	if n == 0 {
+		return nil, nil
+	}
+	start := d.index(i)
+	if end := start + n; end <= d.Cap() {
+		return d.data[start:end:end], nil
+	}
+	end := start + n - d.Cap()
+	return d.data[start:], d.data[:end:end]
+}
+
+// index returns the position in data of the i-th element from the front.
+func (d *Deque[T]) index(i uint) uint {
+	return (d.first + i) % d.Cap()
+}
+
 func (d *Deque[T]) zeroElement() (elem T) { return }
@@ -50,3 +50,68 @@ func BenchmarkSlice_Queue(b *testing.B) {
 		q = append(q, 0)
 	}
 }
+
+// wrapped returns a full deque of n elements that wraps around the end of its buffer.
+func wrapped(n int) Deque[int] {
+	d := New[int](uint(n))
+	d.first = uint(n / 2)
+	for i := range n {
+		d.PushBack(i)
+	}
+	return d
+}
+
+func BenchmarkDeque_PushBackBatch(b *testing.B) {
+	d := New[int](1024)
+	batch := make([]int, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.PushBack(batch...)
+		d.PopNFront(64)
+	}
+}
+func BenchmarkDeque_PushBackLoop(b *testing.B) {
+	d := New[int](1024)
+	batch := make([]int, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, elem := range batch {
+			d.PushBack(elem)
+		}
+		d.PopNFront(64)
+	}
+}
+func BenchmarkDeque_CopyTo(b *testing.B) {
+	d := wrapped(1024)
+	dst := make([]int, 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.CopyTo(dst)
+	}
+}
+func BenchmarkDeque_AppendTo(b *testing.B) {
+	d := wrapped(1024)
+	dst := make([]int, 0, 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst = d.AppendTo(dst[:0])
+	}
+}
+func BenchmarkDeque_CopyLoop(b *testing.B) {
+	d := wrapped(1024)
+	dst := make([]int, 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, elem := range d.All() {
+			dst[j] = elem
+		}
+	}
+}
+func BenchmarkDeque_PopPushCopy(b *testing.B) {
+	d := wrapped(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		elems := d.PopNFront(1024)
+		d.PushBack(elems...)
+	}
+}
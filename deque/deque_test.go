@@ -51,6 +51,14 @@ func TestNew(t *testing.T) {
 	assert.EqualValues(t, 4, d.Cap())
 }
 
+func TestNewWithOptions(t *testing.T) {
+	t.Parallel()
+	d := NewWithOptions[int](Options{Capacity: 4, AutoShrink: true})
+	assert.Zero(t, d.Len())
+	assert.EqualValues(t, 4, d.Cap())
+	assert.True(t, d.autoShrink)
+}
+
 func TestDeque_PushBack(t *testing.T) {
 	t.Parallel()
 	d := Deque[int]{data: make([]int, 2), first: 1, size: 0}
@@ -58,6 +66,10 @@ func TestDeque_PushBack(t *testing.T) {
 	assert.EqualValues(t, []int{0, 1}, d.data)
 	d.PushBack(2, 3)
 	assert.EqualValues(t, []int{0, 1, 2, 3}, d.data)
+
+	d = Deque[int]{data: make([]int, 5), first: 3, size: 1}
+	d.PushBack(1, 2, 3)
+	assert.EqualValues(t, []int{2, 3, 0, 0, 1}, d.data)
 }
 
 func TestDeque_PushFront(t *testing.T) {
@@ -67,6 +79,16 @@ func TestDeque_PushFront(t *testing.T) {
 	assert.EqualValues(t, []int{1, 0}, d.data)
 	d.PushFront(2, 3)
 	assert.EqualValues(t, []int{1, 0, 3, 2}, d.data)
+
+	// The pushed elements wrap around the end of the buffer.
+	d = Deque[int]{data: []int{0, 1, 0, 0, 0}, first: 1, size: 1}
+	d.PushFront(2, 3, 4)
+	assert.EqualValues(t, []int{2, 1, 0, 4, 3}, d.data)
+	d.PushFront(5)
+	assert.EqualValues(t, []int{2, 1, 5, 4, 3}, d.data)
+	d.PushBack(6)
+	d.PushFront(7, 8)
+	assert.EqualValues(t, []int{8, 7, 5, 4, 3, 2, 1, 6}, d.PopNFront(8))
 }
 
 func TestDeque_PopBack(t *testing.T) {
@@ -114,6 +136,20 @@ func TestDeque_PopNBack(t *testing.T) {
 	assert.Equal(t, 4, d.Back())
 	assert.PanicsWithValue(t, "deque: PopNBack() called with n > Len()", func() { d.PopNBack(3) })
 }
+
+// Popping everything from a deque starting at index 0 used to underflow the loop bound.
+func TestDeque_PopNBackFromStart(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 0, size: 2}
+	assert.EqualValues(t, []int{2, 1}, d.PopNBack(2))
+	assert.EqualValues(t, []int{0, 0, 3, 4}, d.data)
+	assert.Empty(t, d.PopNBack(0))
+
+	d = Deque[int]{data: []int{1, 2, 3, 4}, first: 0, size: 4}
+	assert.EqualValues(t, []int{4, 3, 2, 1}, d.PopNBack(4))
+	assert.Zero(t, d.Len())
+}
+
 func TestDeque_PopNFront(t *testing.T) {
 	t.Parallel()
 	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
@@ -121,6 +157,398 @@ func TestDeque_PopNFront(t *testing.T) {
 	assert.EqualValues(t, []int{1, 2, 0, 0}, d.data)
 	assert.Equal(t, 1, d.Front())
 	assert.PanicsWithValue(t, "deque: PopNFront() called with n > Len()", func() { d.PopNFront(3) })
+
+	var empty Deque[int]
+	assert.Empty(t, empty.PopNFront(0))
+}
+
+func TestDeque_TryPop(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	elem, ok := d.TryPopBack()
+	assert.True(t, ok)
+	assert.Equal(t, 2, elem)
+	elem, ok = d.TryPopFront()
+	assert.True(t, ok)
+	assert.Equal(t, 3, elem)
+	d.PopNFront(2)
+	elem, ok = d.TryPopBack()
+	assert.False(t, ok)
+	assert.Zero(t, elem)
+	elem, ok = d.TryPopFront()
+	assert.False(t, ok)
+	assert.Zero(t, elem)
+}
+
+func TestDeque_Peek(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	elem, ok := d.PeekBack()
+	assert.True(t, ok)
+	assert.Equal(t, 2, elem)
+	elem, ok = d.PeekFront()
+	assert.True(t, ok)
+	assert.Equal(t, 3, elem)
+	assert.EqualValues(t, 4, d.Len())
+
+	var empty Deque[int]
+	_, ok = empty.PeekBack()
+	assert.False(t, ok)
+	_, ok = empty.PeekFront()
+	assert.False(t, ok)
+}
+
+func TestDeque_TryPopN(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4, 5, 6}, first: 2, size: 6}
+	assert.EqualValues(t, []int{2, 1}, d.TryPopNBack(2))
+	assert.EqualValues(t, []int{3, 4}, d.TryPopNFront(2))
+	assert.EqualValues(t, []int{5, 6}, d.TryPopNFront(5))
+	assert.Empty(t, d.TryPopNFront(1))
+	assert.Empty(t, d.TryPopNBack(1))
+}
+
+func TestDeque_Slices(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	a, b := d.Slices()
+	assert.EqualValues(t, []int{3, 4}, a)
+	assert.EqualValues(t, []int{1, 2}, b)
+
+	d = Deque[int]{data: []int{1, 2, 3, 4}, first: 1, size: 2}
+	a, b = d.Slices()
+	assert.EqualValues(t, []int{2, 3}, a)
+	assert.Empty(t, b)
+	assert.Equal(t, 2, cap(a))
+
+	var empty Deque[int]
+	a, b = empty.Slices()
+	assert.Empty(t, a)
+	assert.Empty(t, b)
+}
+
+func TestDeque_AppendTo(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	assert.EqualValues(t, []int{0, 3, 4, 1, 2}, d.AppendTo([]int{0}))
+	assert.EqualValues(t, 4, d.Len())
+
+	var empty Deque[int]
+	assert.Nil(t, empty.AppendTo(nil))
+}
+
+func TestDeque_CopyTo(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	dst := make([]int, 5)
+	assert.Equal(t, 4, d.CopyTo(dst))
+	assert.EqualValues(t, []int{3, 4, 1, 2, 0}, dst)
+	dst = make([]int, 3)
+	assert.Equal(t, 3, d.CopyTo(dst))
+	assert.EqualValues(t, []int{3, 4, 1}, dst)
+	dst = make([]int, 1)
+	assert.Equal(t, 1, d.CopyTo(dst))
+	assert.EqualValues(t, []int{3}, dst)
+}
+
+func TestDeque_At(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	assert.Equal(t, 3, d.At(0))
+	assert.Equal(t, 4, d.At(1))
+	assert.Equal(t, 1, d.At(2))
+	assert.Equal(t, 2, d.At(3))
+	assert.PanicsWithValue(t, "deque: At() called with i >= Len()", func() { d.At(4) })
+}
+
+func TestDeque_Set(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	d.Set(0, 30)
+	d.Set(3, 20)
+	assert.EqualValues(t, []int{1, 20, 30, 4}, d.data)
+	assert.PanicsWithValue(t, "deque: Set() called with i >= Len()", func() { d.Set(4, 0) })
+}
+
+func TestDeque_Insert(t *testing.T) {
+	t.Parallel()
+	t.Run("front half", func(t *testing.T) {
+		t.Parallel()
+		d := Deque[int]{data: []int{1, 2, 3, 4, 0, 0}, first: 0, size: 4}
+		d.Insert(1, 5)
+		assert.EqualValues(t, []int{5, 2, 3, 4, 0, 1}, d.data)
+		assert.EqualValues(t, 5, d.first)
+		assert.EqualValues(t, 5, d.Len())
+	})
+	t.Run("back half", func(t *testing.T) {
+		t.Parallel()
+		d := Deque[int]{data: []int{3, 0, 0, 0, 1, 2}, first: 4, size: 3}
+		d.Insert(2, 5)
+		assert.EqualValues(t, []int{5, 3, 0, 0, 1, 2}, d.data)
+		assert.EqualValues(t, 4, d.first)
+	})
+	t.Run("full", func(t *testing.T) {
+		t.Parallel()
+		d := Deque[int]{data: []int{3, 4, 1, 2}, first: 2, size: 4}
+		d.Insert(4, 5)
+		d.Insert(0, 0)
+		d.Insert(3, 9)
+		var elems []int
+		for _, elem := range d.All() {
+			elems = append(elems, elem)
+		}
+		assert.EqualValues(t, []int{0, 1, 2, 9, 3, 4, 5}, elems)
+	})
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		var d Deque[int]
+		d.Insert(0, 1)
+		assert.Equal(t, 1, d.Front())
+		assert.PanicsWithValue(t, "deque: Insert() called with i > Len()", func() { d.Insert(2, 0) })
+	})
+}
+
+func TestDeque_RemoveAt(t *testing.T) {
+	t.Parallel()
+	t.Run("front half", func(t *testing.T) {
+		t.Parallel()
+		d := Deque[int]{data: []int{3, 4, 0, 1, 2}, first: 3, size: 4}
+		assert.Equal(t, 2, d.RemoveAt(1))
+		assert.EqualValues(t, []int{3, 4, 0, 0, 1}, d.data)
+		assert.EqualValues(t, 4, d.first)
+	})
+	t.Run("back half", func(t *testing.T) {
+		t.Parallel()
+		d := Deque[int]{data: []int{3, 4, 0, 1, 2}, first: 3, size: 4}
+		assert.Equal(t, 3, d.RemoveAt(2))
+		assert.EqualValues(t, []int{4, 0, 0, 1, 2}, d.data)
+		assert.EqualValues(t, 3, d.first)
+	})
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+		d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+		assert.Equal(t, 2, d.RemoveAt(3))
+		assert.Equal(t, 3, d.RemoveAt(0))
+		assert.Equal(t, 1, d.RemoveAt(1))
+		assert.Equal(t, 4, d.RemoveAt(0))
+		assert.EqualValues(t, []int{0, 0, 0, 0}, d.data)
+		assert.PanicsWithValue(t, "deque: RemoveAt() called with i >= Len()", func() { d.RemoveAt(0) })
+	})
+}
+
+func TestDeque_Clear(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	d.Clear()
+	assert.Zero(t, d.Len())
+	assert.Zero(t, d.first)
+	assert.EqualValues(t, []int{0, 0, 0, 0}, d.data)
+}
+
+func TestDeque_Grow(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{3, 4, 1, 2}, first: 2, size: 4}
+	d.Grow(0)
+	assert.EqualValues(t, 4, d.Cap())
+	d.Grow(3)
+	assert.EqualValues(t, 8, d.Cap())
+	d.PushBack(5, 6, 7)
+	assert.EqualValues(t, 8, d.Cap())
+	assert.EqualValues(t, []int{1, 2, 3, 4, 5, 6, 7}, d.PopNFront(7))
+}
+
+func TestDeque_ShrinkToFit(t *testing.T) {
+	t.Parallel()
+	for first := range uint(8) {
+		d := Deque[int]{data: make([]int, 8), first: first}
+		d.PushBack(1, 2, 3)
+		d.ShrinkToFit()
+		assert.EqualValues(t, []int{1, 2, 3}, d.data)
+		assert.Zero(t, d.first)
+	}
+	var d Deque[int]
+	d.ShrinkToFit()
+	assert.Zero(t, d.Cap())
+}
+
+func TestDeque_AutoShrink(t *testing.T) {
+	t.Parallel()
+	d := NewWithOptions[int](Options{Capacity: 2, AutoShrink: true})
+	for i := range 16 {
+		d.PushBack(i)
+	}
+	d.PopNFront(10)
+	assert.EqualValues(t, 16, d.Cap())
+	d.PopFront()
+	d.PopBack()
+	assert.EqualValues(t, 8, d.Cap())
+	assert.EqualValues(t, []int{11, 12, 13, 14}, d.data[:d.Len()])
+	d.PopNBack(2)
+	assert.EqualValues(t, 4, d.Cap())
+	d.Clear()
+	assert.EqualValues(t, 2, d.Cap())
+
+	var noShrink Deque[int]
+	noShrink.PushBack(1, 2, 3, 4)
+	noShrink.PopNFront(4)
+	assert.EqualValues(t, 4, noShrink.Cap())
+}
+
+func TestDeque_Overwrite(t *testing.T) {
+	t.Parallel()
+	var evicted []int
+	d := NewWithOptions[int](Options{Capacity: 3, Overflow: Overwrite})
+	d.OnEvict(func(elem int) { evicted = append(evicted, elem) })
+	d.PushBack(1, 2, 3)
+	assert.True(t, d.TryPushBack(4))
+	assert.EqualValues(t, []int{1}, evicted)
+	d.PushBack(5, 6, 7, 8, 9)
+	assert.EqualValues(t, []int{1, 2, 3, 4, 5, 6}, evicted)
+	assert.EqualValues(t, 3, d.Cap())
+	assert.EqualValues(t, []int{7, 8, 9}, d.PopNFront(3))
+
+	evicted = nil
+	d.PushBack(1, 2, 3)
+	assert.True(t, d.TryPushFront(4, 5))
+	assert.EqualValues(t, []int{3, 2}, evicted)
+	d.PushFront(6, 7, 8, 9)
+	assert.EqualValues(t, []int{3, 2, 1, 4, 5, 6}, evicted)
+	assert.EqualValues(t, []int{9, 8, 7}, d.PopNFront(3))
+
+	evicted = nil
+	d.PushBack(1, 2, 3)
+	assert.True(t, d.TryInsert(2, 4))
+	d.Insert(0, 5)
+	assert.EqualValues(t, []int{1, 5}, evicted)
+	assert.EqualValues(t, []int{2, 4, 3}, d.PopNFront(3))
+
+	evicted = nil
+	d.OnEvict(nil)
+	d.PushBack(1, 2, 3, 4)
+	assert.Nil(t, evicted)
+}
+
+func TestDeque_Reject(t *testing.T) {
+	t.Parallel()
+	d := NewWithOptions[int](Options{Capacity: 3, Overflow: Reject})
+	d.PushBack(1, 2)
+	assert.False(t, d.TryPushBack(3, 4))
+	assert.False(t, d.TryPushFront(3, 4))
+	assert.True(t, d.TryPushFront(3))
+	assert.False(t, d.TryPushBack(4))
+	assert.False(t, d.TryInsert(1, 4))
+	assert.True(t, d.TryPushBack())
+	assert.PanicsWithValue(t, "deque: PushBack() called on full deque", func() { d.PushBack(4) })
+	assert.PanicsWithValue(t, "deque: PushFront() called on full deque", func() { d.PushFront(4) })
+	assert.PanicsWithValue(t, "deque: Insert() called on full deque", func() { d.Insert(1, 4) })
+	assert.EqualValues(t, []int{3, 1, 2}, d.PopNFront(3))
+}
+
+func TestDeque_BoundedGrowth(t *testing.T) {
+	t.Parallel()
+	d := NewWithOptions[int](Options{Capacity: 5, Overflow: Overwrite})
+	d.ShrinkToFit()
+	assert.Zero(t, d.Cap())
+	d.PushBack(1)
+	assert.EqualValues(t, 1, d.Cap())
+	d.PushBack(2, 3)
+	assert.EqualValues(t, 4, d.Cap())
+	d.PushBack(4, 5, 6)
+	assert.EqualValues(t, 5, d.Cap())
+	d.Grow(10)
+	assert.EqualValues(t, 5, d.Cap())
+	assert.EqualValues(t, []int{2, 3, 4, 5, 6}, d.PopNFront(5))
+}
+
+func TestDeque_ZeroCapacity(t *testing.T) {
+	t.Parallel()
+	var evicted []int
+	d := NewWithOptions[int](Options{Overflow: Overwrite})
+	d.OnEvict(func(elem int) { evicted = append(evicted, elem) })
+	d.PushBack(1)
+	d.PushFront(2)
+	d.Insert(0, 3)
+	assert.Equal(t, []int{1, 2, 3}, evicted)
+	assert.Zero(t, d.Len())
+	assert.Zero(t, d.Cap())
+
+	r := NewWithOptions[int](Options{Overflow: Reject})
+	assert.False(t, r.TryPushBack(1))
+	assert.False(t, r.TryPushFront(1))
+	assert.False(t, r.TryInsert(0, 1))
+	assert.True(t, r.TryPushFront())
+	assert.Zero(t, r.Len())
+
+	var empty Deque[int]
+	empty.PushFront()
+	assert.Zero(t, empty.Len())
+}
+
+func TestDeque_All(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	var idx []uint
+	var elems []int
+	for i, elem := range d.All() {
+		idx = append(idx, i)
+		elems = append(elems, elem)
+	}
+	assert.EqualValues(t, []uint{0, 1, 2, 3}, idx)
+	assert.EqualValues(t, []int{3, 4, 1, 2}, elems)
+
+	elems = elems[:0]
+	for _, elem := range d.All() {
+		if elem == 1 {
+			break
+		}
+		elems = append(elems, elem)
+	}
+	assert.EqualValues(t, []int{3, 4}, elems)
+
+	var empty Deque[int]
+	for range empty.All() {
+		t.Fail()
+	}
+}
+
+func TestDeque_Backward(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	var idx []uint
+	var elems []int
+	for i, elem := range d.Backward() {
+		idx = append(idx, i)
+		elems = append(elems, elem)
+	}
+	assert.EqualValues(t, []uint{3, 2, 1, 0}, idx)
+	assert.EqualValues(t, []int{2, 1, 4, 3}, elems)
+
+	var empty Deque[int]
+	for range empty.Backward() {
+		t.Fail()
+	}
+}
+
+func TestDeque_PopAllFront(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{1, 2, 3, 4}, first: 2, size: 4}
+	var elems []int
+	for elem := range d.PopAllFront() {
+		elems = append(elems, elem)
+		if elem == 4 {
+			break
+		}
+	}
+	assert.EqualValues(t, []int{3, 4}, elems)
+	assert.EqualValues(t, 2, d.Len())
+	assert.EqualValues(t, []int{1, 2, 0, 0}, d.data)
+
+	elems = elems[:0]
+	for elem := range d.PopAllFront() {
+		elems = append(elems, elem)
+	}
+	assert.EqualValues(t, []int{1, 2}, elems)
+	assert.Zero(t, d.Len())
 }
 
 func TestEnsureCapacity(t *testing.T) {
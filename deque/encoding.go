@@ -0,0 +1,64 @@
+package deque
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON encodes the deque as a JSON array of its elements, from front to back.
+func (d Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.AppendTo(make([]T, 0, d.size)))
+}
+
+// UnmarshalJSON replaces the elements of the deque with the ones in a JSON array, from front to back.
+// The resulting deque is compact: its capacity equals its length.
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	var elems []T
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	return d.load(elems)
+}
+
+// GobEncode encodes the elements of the deque, from front to back, using encoding/gob.
+func (d Deque[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(d.AppendTo(make([]T, 0, d.size))); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the elements of the deque with the ones encoded by GobEncode.
+// The resulting deque is compact: its capacity equals its length.
+func (d *Deque[T]) GobDecode(data []byte) error {
+	var elems []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&elems); err != nil {
+		return err
+	}
+	return d.load(elems)
+}
+
+// MarshalBinary is the same as GobEncode.
+func (d Deque[T]) MarshalBinary() ([]byte, error) {
+	return d.GobEncode()
+}
+
+// UnmarshalBinary is the same as GobDecode.
+func (d *Deque[T]) UnmarshalBinary(data []byte) error {
+	return d.GobDecode(data)
+}
+
+// load replaces the elements of the deque with elems, using elems as the new buffer.
+// The options of the deque are kept, so elems must fit into a bounded deque.
+func (d *Deque[T]) load(elems []T) error {
+	if d.overflow != Unbounded && uint(len(elems)) > d.limit {
+		return fmt.Errorf("deque: %d elements don't fit into a bounded deque of capacity %d", len(elems), d.limit)
+	}
+	d.data = elems[:len(elems):len(elems)]
+	d.first = 0
+	d.size = uint(len(elems))
+	return nil
+}
@@ -0,0 +1,75 @@
+package deque
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeque_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{3, 4, 0, 0, 1, 2}, first: 4, size: 4}
+	data, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2,3,4]", string(data))
+	data, err = json.Marshal(&d)
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2,3,4]", string(data))
+
+	var empty Deque[int]
+	data, err = json.Marshal(empty)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+}
+
+func TestDeque_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	d := Deque[string]{data: []string{"x", "", "y"}, first: 2, size: 2}
+	assert.NoError(t, json.Unmarshal([]byte(`["a","b","c"]`), &d))
+	assert.EqualValues(t, []string{"a", "b", "c"}, d.data)
+	assert.Zero(t, d.first)
+	assert.EqualValues(t, 3, d.Len())
+	assert.EqualValues(t, 3, d.Cap())
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &d))
+	assert.Zero(t, d.Len())
+	assert.Error(t, json.Unmarshal([]byte(`{}`), &d))
+
+	bounded := NewWithOptions[string](Options{Capacity: 2, Overflow: Reject})
+	assert.Error(t, json.Unmarshal([]byte(`["a","b","c"]`), &bounded))
+	assert.NoError(t, json.Unmarshal([]byte(`["a","b"]`), &bounded))
+	assert.False(t, bounded.TryPushBack("c"))
+}
+
+func TestDeque_Gob(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{3, 4, 0, 0, 1, 2}, first: 4, size: 4}
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(d))
+	var decoded Deque[int]
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	assert.EqualValues(t, []int{1, 2, 3, 4}, decoded.data)
+	assert.Zero(t, decoded.first)
+	assert.EqualValues(t, 4, decoded.Len())
+}
+
+func TestDeque_MarshalBinary(t *testing.T) {
+	t.Parallel()
+	d := Deque[int]{data: []int{3, 4, 0, 0, 1, 2}, first: 4, size: 4}
+	data, err := d.MarshalBinary()
+	assert.NoError(t, err)
+	var decoded Deque[int]
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.EqualValues(t, []int{1, 2, 3, 4}, decoded.data)
+
+	var empty Deque[int]
+	data, err = empty.MarshalBinary()
+	assert.NoError(t, err)
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Zero(t, decoded.Len())
+
+	assert.Error(t, decoded.UnmarshalBinary([]byte("garbage")))
+}
@@ -0,0 +1,104 @@
+package deque
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+const cacheLineSize = 64
+
+// SPSC is a bounded lock-free queue for exactly one producer and one consumer goroutine.
+// Enqueue must only ever be called by the producer and Dequeue/DequeueN only by the consumer,
+// otherwise no synchronisation is needed.
+//
+// The capacity is rounded up to a power of two so positions in the ring buffer can be found
+// by masking the ever increasing head and tail counters instead of using a modulo.
+// Fields written by the producer and by the consumer live on separate cache lines.
+type SPSC[T any] struct {
+	_ [cacheLineSize]byte
+	// Owned by the consumer.
+	head       atomic.Uint64
+	cachedTail uint64
+	_          [cacheLineSize - 16]byte
+	// Owned by the producer.
+	tail       atomic.Uint64
+	cachedHead uint64
+	_          [cacheLineSize - 16]byte
+	// Read-only after construction.
+	mask uint64
+	data []T
+	_    [cacheLineSize - 32]byte
+}
+
+// NewSPSC creates an SPSC queue that can hold at least capacity elements.
+func NewSPSC[T any](capacity uint) *SPSC[T] {
+	c := uint64(1)
+	if capacity > 1 {
+		c <<= bits.Len64(uint64(capacity) - 1)
+	}
+	return &SPSC[T]{mask: c - 1, data: make([]T, c)}
+}
+
+// Len returns the number of elements in the queue. It is only a snapshot when called
+// while the producer or the consumer are active.
+func (q *SPSC[T]) Len() uint {
+	head := q.head.Load()
+	return uint(q.tail.Load() - head)
+}
+
+// Cap returns the capacity of the queue.
+func (q *SPSC[T]) Cap() uint {
+	return uint(len(q.data))
+}
+
+// Enqueue adds an element to the back of the queue. It returns false if the queue is full.
+// Must only be called by the producer.
+func (q *SPSC[T]) Enqueue(elem T) bool {
+	tail := q.tail.Load()
+	if tail-q.cachedHead == uint64(len(q.data)) {
+		q.cachedHead = q.head.Load()
+		if tail-q.cachedHead == uint64(len(q.data)) {
+			return false
+		}
+	}
+	q.data[tail&q.mask] = elem
+	q.tail.Store(tail + 1)
+	return true
+}
+
+// Dequeue removes and returns the element at the front of the queue if there is one.
+// Must only be called by the consumer.
+func (q *SPSC[T]) Dequeue() (elem T, ok bool) {
+	head := q.head.Load()
+	if head == q.cachedTail {
+		q.cachedTail = q.tail.Load()
+		if head == q.cachedTail {
+			return
+		}
+	}
+	idx := head & q.mask
+	elem, q.data[idx] = q.data[idx], elem
+	q.head.Store(head + 1)
+	return elem, true
+}
+
+// DequeueN removes up to len(dst) elements from the front of the queue and copies them into dst.
+// It returns the number of elements copied. Must only be called by the consumer.
+func (q *SPSC[T]) DequeueN(dst []T) int {
+	head := q.head.Load()
+	if head+uint64(len(dst)) > q.cachedTail {
+		q.cachedTail = q.tail.Load()
+	}
+	n := min(uint64(len(dst)), q.cachedTail-head)
+	if n == 0 {
+		return 0
+	}
+	start := head & q.mask
+	end := min(start+n, uint64(len(q.data)))
+	k := copy(dst, q.data[start:end])
+	clear(q.data[start:end])
+	copy(dst[k:n], q.data[:n-uint64(k)])
+	clear(q.data[:n-uint64(k)])
+	q.head.Store(head + n)
+	return int(n)
+}
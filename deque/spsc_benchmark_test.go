@@ -0,0 +1,73 @@
+package deque
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func BenchmarkSPSC(b *testing.B) {
+	q := NewSPSC[int](1024)
+	go func() {
+		for i := 0; i < b.N; i++ {
+			for !q.Enqueue(i) {
+				runtime.Gosched()
+			}
+		}
+	}()
+	for i := 0; i < b.N; {
+		if elem, ok := q.Dequeue(); ok {
+			sink = elem
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+func BenchmarkSPSC_DequeueN(b *testing.B) {
+	q := NewSPSC[int](1024)
+	go func() {
+		for i := 0; i < b.N; i++ {
+			for !q.Enqueue(i) {
+				runtime.Gosched()
+			}
+		}
+	}()
+	dst := make([]int, 64)
+	for i := 0; i < b.N; {
+		if k := q.DequeueN(dst); k > 0 {
+			i += k
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+func BenchmarkMutexDeque(b *testing.B) {
+	var lock sync.Mutex
+	d := New[int](1024)
+	go func() {
+		for i := 0; i < b.N; {
+			lock.Lock()
+			full := d.Len() == 1024
+			if !full {
+				d.PushBack(i)
+				i++
+			}
+			lock.Unlock()
+			if full {
+				runtime.Gosched()
+			}
+		}
+	}()
+	for i := 0; i < b.N; {
+		lock.Lock()
+		elem, ok := d.TryPopFront()
+		lock.Unlock()
+		if ok {
+			sink = elem
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
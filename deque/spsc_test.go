@@ -0,0 +1,126 @@
+package deque
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSPSC() {
+	queue := NewSPSC[int](3)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= 5; i++ {
+			for !queue.Enqueue(i) {
+				runtime.Gosched()
+			}
+		}
+	}()
+	sum := 0
+	for i := 0; i < 5; {
+		if elem, ok := queue.Dequeue(); ok {
+			sum += elem
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+	<-done
+	fmt.Println(queue.Cap(), sum)
+
+	// Output:
+	// 4 15
+}
+
+func TestNewSPSC(t *testing.T) {
+	t.Parallel()
+	for capacity, expected := range map[uint]uint{0: 1, 1: 1, 2: 2, 3: 4, 4: 4, 5: 8, 1000: 1024} {
+		q := NewSPSC[int](capacity)
+		assert.Equal(t, expected, q.Cap(), "capacity %d", capacity)
+		assert.Zero(t, q.Len())
+	}
+}
+
+func TestSPSC_EnqueueDequeue(t *testing.T) {
+	t.Parallel()
+	q := NewSPSC[int](4)
+	_, ok := q.Dequeue()
+	assert.False(t, ok)
+	for i := range 4 {
+		assert.True(t, q.Enqueue(i))
+	}
+	assert.False(t, q.Enqueue(4))
+	assert.EqualValues(t, 4, q.Len())
+	for i := range 3 {
+		elem, ok := q.Dequeue()
+		assert.True(t, ok)
+		assert.Equal(t, i, elem)
+	}
+	assert.True(t, q.Enqueue(4))
+	assert.True(t, q.Enqueue(5))
+	assert.EqualValues(t, []int{4, 5, 0, 3}, q.data)
+	for i := 3; i < 6; i++ {
+		elem, ok := q.Dequeue()
+		assert.True(t, ok)
+		assert.Equal(t, i, elem)
+	}
+	_, ok = q.Dequeue()
+	assert.False(t, ok)
+	assert.EqualValues(t, []int{0, 0, 0, 0}, q.data)
+}
+
+func TestSPSC_DequeueN(t *testing.T) {
+	t.Parallel()
+	q := NewSPSC[int](4)
+	dst := make([]int, 3)
+	assert.Zero(t, q.DequeueN(dst))
+	q.Enqueue(0)
+	q.Enqueue(1)
+	q.Enqueue(2)
+	assert.Equal(t, 2, q.DequeueN(dst[:2]))
+	assert.EqualValues(t, []int{0, 1, 0}, dst)
+	q.Enqueue(3)
+	q.Enqueue(4)
+	q.Enqueue(5)
+	assert.Equal(t, 3, q.DequeueN(dst))
+	assert.EqualValues(t, []int{2, 3, 4}, dst)
+	assert.Equal(t, 1, q.DequeueN(dst))
+	assert.EqualValues(t, []int{5, 3, 4}, dst)
+	assert.EqualValues(t, []int{0, 0, 0, 0}, q.data)
+}
+
+// TestSPSC_Concurrent is meant to be run with the race detector.
+func TestSPSC_Concurrent(t *testing.T) {
+	t.Parallel()
+	q := NewSPSC[int](16)
+	n := 100000
+	go func() {
+		for i := range n {
+			for !q.Enqueue(i) {
+				runtime.Gosched()
+			}
+		}
+	}()
+	dst := make([]int, 5)
+	for next := 0; next < n; {
+		if next%2 == 0 {
+			k := q.DequeueN(dst)
+			for _, elem := range dst[:k] {
+				assert.Equal(t, next, elem)
+				next++
+			}
+			if k == 0 {
+				runtime.Gosched()
+			}
+		} else if elem, ok := q.Dequeue(); ok {
+			assert.Equal(t, next, elem)
+			next++
+		} else {
+			runtime.Gosched()
+		}
+	}
+	assert.Zero(t, q.Len())
+}
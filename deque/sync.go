@@ -0,0 +1,162 @@
+package deque
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when waiting for elements of a closed and drained Sync deque.
+var ErrClosed = errors.New("deque: closed")
+
+// Sync is a wrapper around Deque ensuring that all operations are properly synchronised
+// using an internal mutex. Consumers can wait for elements, which makes it usable as a work queue.
+type Sync[T any] struct {
+	nosync Deque[T]
+	lock   sync.Mutex
+	// changed is closed and reset whenever elements are pushed or the deque is closed.
+	// It is only allocated when someone is waiting.
+	changed chan struct{}
+	closed  bool
+	// evicted collects the elements evicted while the lock is held, they are passed to onEvict
+	// after it is released.
+	evicted []T
+	onEvict func(T)
+}
+
+// NewSync creates a Sync deque with the given initial capacity.
+func NewSync[T any](capacity uint) *Sync[T] {
+	return &Sync[T]{nosync: New[T](capacity)}
+}
+
+// NewSyncWithOptions creates a Sync deque configured by opts.
+func NewSyncWithOptions[T any](opts Options) *Sync[T] {
+	return &Sync[T]{nosync: NewWithOptions[T](opts)}
+}
+
+// OnEvict sets a callback that is called with every element evicted by the Overwrite policy.
+// A nil callback removes the previous one. The callback is called after the push that evicted
+// the elements released the lock, so it may use the deque, but callbacks of concurrent pushes
+// may run in any order.
+func (s *Sync[T]) OnEvict(fn func(elem T)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.onEvict = fn
+	if fn == nil {
+		s.nosync.OnEvict(nil)
+		return
+	}
+	s.nosync.OnEvict(func(elem T) { s.evicted = append(s.evicted, elem) })
+}
+
+// Len returns the number of elements in the deque.
+func (s *Sync[T]) Len() uint {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.nosync.Len()
+}
+
+// PushBack adds elements to the back of the deque and wakes up waiting consumers.
+// It returns false if the deque is closed or if it is full and rejects the elements.
+func (s *Sync[T]) PushBack(elems ...T) bool {
+	s.lock.Lock()
+	if s.closed || !s.nosync.TryPushBack(elems...) {
+		s.lock.Unlock()
+		return false
+	}
+	s.notify()
+	s.unlockAndEvict()
+	return true
+}
+
+// PushFront adds elements to the front of the deque and wakes up waiting consumers.
+// It returns false if the deque is closed or if it is full and rejects the elements.
+func (s *Sync[T]) PushFront(elems ...T) bool {
+	s.lock.Lock()
+	if s.closed || !s.nosync.TryPushFront(elems...) {
+		s.lock.Unlock()
+		return false
+	}
+	s.notify()
+	s.unlockAndEvict()
+	return true
+}
+
+// TryPopFront removes and returns the element at the front of the deque if there is one.
+func (s *Sync[T]) TryPopFront() (T, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.nosync.TryPopFront()
+}
+
+// TryPopBack removes and returns the element at the back of the deque if there is one.
+func (s *Sync[T]) TryPopBack() (T, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.nosync.TryPopBack()
+}
+
+// PopFrontWait removes and returns the element at the front of the deque, waiting for one
+// to be pushed if the deque is empty. It returns ctx.Err() if ctx expires first and ErrClosed
+// once the deque is closed and empty.
+func (s *Sync[T]) PopFrontWait(ctx context.Context) (T, error) {
+	return s.popWait(ctx, (*Deque[T]).PopFront)
+}
+
+// PopBackWait removes and returns the element at the back of the deque, waiting for one
+// to be pushed if the deque is empty. It returns ctx.Err() if ctx expires first and ErrClosed
+// once the deque is closed and empty.
+func (s *Sync[T]) PopBackWait(ctx context.Context) (T, error) {
+	return s.popWait(ctx, (*Deque[T]).PopBack)
+}
+
+// Close stops the deque accepting new elements and wakes up all waiting consumers.
+// Elements already in the deque can still be popped.
+func (s *Sync[T]) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closed = true
+	s.notify()
+}
+
+func (s *Sync[T]) popWait(ctx context.Context, pop func(*Deque[T]) T) (elem T, err error) {
+	s.lock.Lock()
+	for s.nosync.Len() == 0 {
+		if s.closed {
+			s.lock.Unlock()
+			return elem, ErrClosed
+		}
+		if s.changed == nil {
+			s.changed = make(chan struct{})
+		}
+		changed := s.changed
+		s.lock.Unlock()
+		select {
+		case <-ctx.Done():
+			return elem, ctx.Err()
+		case <-changed:
+		}
+		s.lock.Lock()
+	}
+	defer s.lock.Unlock()
+	return pop(&s.nosync), nil
+}
+
+// unlockAndEvict releases the lock and then passes the elements evicted while it was held to
+// the OnEvict callback.
+func (s *Sync[T]) unlockAndEvict() {
+	evicted, onEvict := s.evicted, s.onEvict
+	s.evicted = nil
+	s.lock.Unlock()
+	for _, elem := range evicted {
+		onEvict(elem)
+	}
+}
+
+// notify wakes up everyone waiting on the changed channel. Must be called with the lock held.
+func (s *Sync[T]) notify() {
+	if s.changed != nil {
+		close(s.changed)
+		s.changed = nil
+	}
+}
@@ -0,0 +1,209 @@
+package deque
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSync() {
+	queue := NewSync[int](4)
+	ctx := context.Background()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			elem, err := queue.PopFrontWait(ctx)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Println(elem)
+		}
+	}()
+	queue.PushBack(1, 2)
+	queue.PushBack(3)
+	queue.Close()
+	<-done
+
+	// Output:
+	// 1
+	// 2
+	// 3
+	// deque: closed
+}
+
+// waitForConsumer returns once a consumer is waiting for elements to be pushed to s.
+func waitForConsumer[T any](s *Sync[T]) {
+	for {
+		s.lock.Lock()
+		waiting := s.changed != nil
+		s.lock.Unlock()
+		if waiting {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestSync_TryPop(t *testing.T) {
+	t.Parallel()
+	s := NewSync[int](0)
+	_, ok := s.TryPopFront()
+	assert.False(t, ok)
+	_, ok = s.TryPopBack()
+	assert.False(t, ok)
+
+	assert.True(t, s.PushBack(1, 2))
+	assert.True(t, s.PushFront(0))
+	assert.EqualValues(t, 3, s.Len())
+	elem, ok := s.TryPopFront()
+	assert.True(t, ok)
+	assert.Equal(t, 0, elem)
+	elem, ok = s.TryPopBack()
+	assert.True(t, ok)
+	assert.Equal(t, 2, elem)
+}
+
+func TestSync_PopWait(t *testing.T) {
+	t.Parallel()
+	s := NewSync[int](0)
+	ctx := context.Background()
+
+	s.PushBack(1, 2, 3)
+	elem, err := s.PopFrontWait(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, elem)
+	elem, err = s.PopBackWait(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, elem)
+	elem, err = s.PopBackWait(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, elem)
+
+	go func() {
+		waitForConsumer(s)
+		s.PushBack(4)
+	}()
+	elem, err = s.PopBackWait(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, elem)
+}
+
+func TestSync_PopWaitCancel(t *testing.T) {
+	t.Parallel()
+	s := NewSync[int](0)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		waitForConsumer(s)
+		cancel()
+	}()
+	_, err := s.PopFrontWait(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSync_Close(t *testing.T) {
+	t.Parallel()
+	s := NewSync[int](0)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	n := 10
+	wg.Add(n)
+	for range n {
+		go func() {
+			defer wg.Done()
+			_, err := s.PopFrontWait(ctx)
+			assert.ErrorIs(t, err, ErrClosed)
+		}()
+	}
+	// Consumers that aren't waiting yet when the deque is closed return right away.
+	waitForConsumer(s)
+	s.Close()
+	wg.Wait()
+	assert.False(t, s.PushBack(1))
+	assert.False(t, s.PushFront(1))
+}
+
+func TestSync_CloseDrains(t *testing.T) {
+	t.Parallel()
+	s := NewSync[int](0)
+	ctx := context.Background()
+	s.PushBack(1)
+	s.Close()
+	elem, err := s.PopFrontWait(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, elem)
+	_, err = s.PopFrontWait(ctx)
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestSync_Bounded(t *testing.T) {
+	t.Parallel()
+	s := NewSyncWithOptions[int](Options{Capacity: 1, Overflow: Reject})
+	assert.True(t, s.PushBack(1))
+	assert.False(t, s.PushBack(2))
+}
+
+func TestSync_OnEvict(t *testing.T) {
+	t.Parallel()
+	s := NewSyncWithOptions[int](Options{Capacity: 2, Overflow: Overwrite})
+	var evicted []int
+	// The callback can use the deque without deadlocking.
+	s.OnEvict(func(elem int) {
+		evicted = append(evicted, elem)
+		assert.EqualValues(t, 2, s.Len())
+	})
+	assert.True(t, s.PushBack(1, 2, 3))
+	assert.True(t, s.PushFront(4))
+	assert.Equal(t, []int{1, 3}, evicted)
+
+	s.OnEvict(nil)
+	assert.True(t, s.PushBack(5))
+	assert.Equal(t, []int{1, 3}, evicted)
+	elem, ok := s.TryPopFront()
+	assert.True(t, ok)
+	assert.Equal(t, 2, elem)
+}
+
+func TestSync_WorkQueue(t *testing.T) {
+	t.Parallel()
+	s := NewSync[int](0)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	sum := 0
+	consumers, producers, n := 4, 4, 1000
+	wg.Add(consumers)
+	for range consumers {
+		go func() {
+			defer wg.Done()
+			for {
+				elem, err := s.PopFrontWait(ctx)
+				if err != nil {
+					return
+				}
+				lock.Lock()
+				sum += elem
+				lock.Unlock()
+			}
+		}()
+	}
+	var pwg sync.WaitGroup
+	pwg.Add(producers)
+	for range producers {
+		go func() {
+			defer pwg.Done()
+			for i := range n {
+				s.PushBack(i)
+			}
+		}()
+	}
+	pwg.Wait()
+	s.Close()
+	wg.Wait()
+	assert.Equal(t, producers*n*(n-1)/2, sum)
+}
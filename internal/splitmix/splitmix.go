@@ -0,0 +1,13 @@
+// splitmix holds the hash finalizer shared by the set packages.
+package splitmix
+
+// Mix is the finalizer of splitmix64, it spreads every bit of x to all the bits of the result.
+// Encoded bloom filters depend on it, so it must never change.
+func Mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
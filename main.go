@@ -2,24 +2,19 @@ package main
 
 import (
 	"github.com/bitstonks/go-adt/set"
-
-	"sort"
 )
 
 type ynt struct{ n int }
 
 func pset(s set.Set[ynt]) {
-	i := 0
-	keys := make([]ynt, len(s))
+	var keys set.Ordered[int]
 	for e := range s {
-		keys[i] = e
-		i += 1
+		keys.Add(e.n)
 	}
-	sort.Slice(keys, func(i, j int) bool { return keys[i].n < keys[j].n })
 
 	sep := ""
-	for i := range keys {
-		print(sep, keys[i].n)
+	for n := range keys.All() {
+		print(sep, n)
 		sep = " "
 	}
 	print("\n")
@@ -0,0 +1,266 @@
+package set
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// wordSize is the number of keys stored in every word of a Bits set.
+const wordSize = 64
+
+// Bits is a set of small non-negative integers backed by a bitset. It takes one bit for every
+// integer up to the largest key in the set, so it is much more compact and faster than Set[int]
+// when the keys are dense. The set operations work on whole words at a time. The zero value is
+// an empty set ready to use. Bits sets must not be copied by value, use Copy() instead.
+type Bits struct {
+	// words never ends with a zero word, so that equal sets have equal words.
+	words []uint64
+}
+
+// NewBits creates a new bitset that contains all the given keys.
+// It panics if any of the keys is negative.
+func NewBits(keys ...int) *Bits {
+	s := &Bits{}
+	if len(keys) > 0 {
+		s.grow(max(slices.Max(keys), 0)/wordSize + 1)
+	}
+	for i := range keys {
+		s.add(keys[i])
+	}
+	s.trim()
+	return s
+}
+
+// BitsFromSet creates a new bitset that contains all the keys of the set.
+// It panics if any of the keys is negative.
+func BitsFromSet(set Set[int]) *Bits {
+	return NewBits(set.Elements()...)
+}
+
+// ToSet creates a new Set that contains all the keys of the bitset.
+func (s *Bits) ToSet() Set[int] {
+	resultset := make(Set[int], s.Len())
+	for k := range s.All() {
+		resultset[k] = struct{}{}
+	}
+	return resultset
+}
+
+// Len returns the number of keys in the set.
+func (s *Bits) Len() int {
+	n := 0
+	for _, w := range s.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Copy creates a deep copy of the set.
+func (s *Bits) Copy() *Bits {
+	return &Bits{words: slices.Clone(s.words)}
+}
+
+// Elements returns all the keys in the set in ascending order.
+func (s *Bits) Elements() []int {
+	keys := make([]int, 0, s.Len())
+	for k := range s.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// All returns an iterator over all the keys in the set in ascending order.
+// The set must not be modified during the iteration.
+func (s *Bits) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range s.words {
+			for w != 0 {
+				if !yield(i*wordSize + bits.TrailingZeros64(w)) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// NextSet returns the smallest key in the set that is greater or equal to i, if there is one.
+// All the keys can be visited with:
+//
+//	for k, ok := s.NextSet(0); ok; k, ok = s.NextSet(k + 1) {
+//		...
+//	}
+func (s *Bits) NextSet(i int) (next int, ok bool) {
+	i = max(i, 0)
+	wi := i / wordSize
+	if wi >= len(s.words) {
+		return 0, false
+	}
+	if w := s.words[wi] >> (i % wordSize); w != 0 {
+		return i + bits.TrailingZeros64(w), true
+	}
+	for wi++; wi < len(s.words); wi++ {
+		if w := s.words[wi]; w != 0 {
+			return wi*wordSize + bits.TrailingZeros64(w), true
+		}
+	}
+	return 0, false
+}
+
+// Contains checks if the set contains all of the given keys.
+func (s *Bits) Contains(key int, keys ...int) bool {
+	if !s.has(key) {
+		return false
+	}
+	for i := range keys {
+		if !s.has(keys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add inserts keys into the set. It panics if any of the keys is negative.
+func (s *Bits) Add(key int, keys ...int) {
+	s.add(key)
+	for i := range keys {
+		s.add(keys[i])
+	}
+}
+
+// Del removes keys from the set.
+func (s *Bits) Del(key int, keys ...int) {
+	// An empty set contains no keys.
+	if len(s.words) == 0 {
+		return
+	}
+
+	s.del(key)
+	for i := range keys {
+		s.del(keys[i])
+	}
+	s.trim()
+}
+
+// Equal checks if the sets contain the same keys.
+func (s *Bits) Equal(other *Bits) bool {
+	return slices.Equal(s.words, other.words)
+}
+
+// Union returns the union of all the sets: ⋃(s, other, others) = s ∪ other ∪ others[0] ∪ others[1] ...
+func (s *Bits) Union(other *Bits, others ...*Bits) *Bits {
+	resultset := s.Copy()
+	resultset.update(other)
+	for i := range others {
+		resultset.update(others[i])
+	}
+	return resultset
+}
+
+// Intersection returns the intersection of all the sets: ⋂(s, other, others) = s ∩ other ∩ others[0] ∩ others[1] ...
+func (s *Bits) Intersection(other *Bits, others ...*Bits) *Bits {
+	resultset := s.Copy()
+	resultset.intersect(other)
+	for i := range others {
+		resultset.intersect(others[i])
+	}
+	resultset.trim()
+	return resultset
+}
+
+// Difference returns the difference of all the sets: s ∖ other ∖ others[0] ∖ others[1] ...
+func (s *Bits) Difference(other *Bits, others ...*Bits) *Bits {
+	// The difference of a set with itself is the empty set.
+	if s == other {
+		return &Bits{}
+	}
+
+	resultset := s.Copy()
+	resultset.remove(other)
+	for i := range others {
+		resultset.remove(others[i])
+	}
+	resultset.trim()
+	return resultset
+}
+
+// SymmetricDifference returns the difference between the union and intersection
+// of all the sets: ⋃(s, other, others) ∖ ⋂(s, other, others).
+func (s *Bits) SymmetricDifference(other *Bits, others ...*Bits) *Bits {
+	// The symmetric difference of a set with itself is the empty set.
+	if len(others) == 0 && s == other {
+		return &Bits{}
+	}
+
+	if len(others) == 0 {
+		resultset := s.Copy()
+		resultset.grow(len(other.words))
+		for i, w := range other.words {
+			resultset.words[i] ^= w
+		}
+		resultset.trim()
+		return resultset
+	}
+	resultset := s.Union(other, others...)
+	resultset.remove(s.Intersection(other, others...))
+	resultset.trim()
+	return resultset
+}
+
+func (s *Bits) has(key int) bool {
+	return key >= 0 && key/wordSize < len(s.words) && s.words[key/wordSize]&(1<<(key%wordSize)) != 0
+}
+
+func (s *Bits) add(key int) {
+	if key < 0 {
+		panic("set: Bits key must not be negative")
+	}
+	s.grow(key/wordSize + 1)
+	s.words[key/wordSize] |= 1 << (key % wordSize)
+}
+
+func (s *Bits) del(key int) {
+	if key >= 0 && key/wordSize < len(s.words) {
+		s.words[key/wordSize] &^= 1 << (key % wordSize)
+	}
+}
+
+func (s *Bits) update(other *Bits) {
+	s.grow(len(other.words))
+	for i, w := range other.words {
+		s.words[i] |= w
+	}
+}
+
+// intersect leaves trailing zero words, call trim() afterwards.
+func (s *Bits) intersect(other *Bits) {
+	s.words = s.words[:min(len(s.words), len(other.words))]
+	for i := range s.words {
+		s.words[i] &= other.words[i]
+	}
+}
+
+// remove leaves trailing zero words, call trim() afterwards.
+func (s *Bits) remove(other *Bits) {
+	for i := range min(len(s.words), len(other.words)) {
+		s.words[i] &^= other.words[i]
+	}
+}
+
+// grow makes sure the set has at least n words.
+func (s *Bits) grow(n int) {
+	if l := len(s.words); n > l {
+		s.words = slices.Grow(s.words, n-l)[:n]
+		clear(s.words[l:])
+	}
+}
+
+// trim drops the trailing zero words.
+func (s *Bits) trim() {
+	n := len(s.words)
+	for n > 0 && s.words[n-1] == 0 {
+		n--
+	}
+	s.words = s.words[:n]
+}
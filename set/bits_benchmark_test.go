@@ -0,0 +1,205 @@
+package set
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomBitsKeyArray(size uint, limit int) []int {
+	a := make([]int, 0, size)
+	for i := uint(0); i < size; i++ {
+		a = append(a, rand.Intn(limit))
+	}
+	return a
+}
+
+var (
+	randomBitsKeys_1000 []int = randomBitsKeyArray(1000, 4096)
+	nullBits                  = NewBits()
+	randomBitsA_1000          = NewBits(randomBitsKeys_1000...)
+	randomBitsB_1000          = NewBits(randomBitsKeys_1000...)
+	randomBitsX_1000          = NewBits(randomBitsKeyArray(1000, 4096)...)
+	randomBitsX_2000          = NewBits(randomBitsKeyArray(2000, 8192)...)
+	randomBitsSet_1000        = New(randomBitsKeys_1000...)
+)
+
+func BenchmarkNewBits(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewBits()
+	}
+}
+
+func BenchmarkNewBitsFill(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewBits(randomBitsKeys_1000...)
+	}
+}
+
+func BenchmarkBitsFromSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		BitsFromSet(randomBitsSet_1000)
+	}
+}
+
+func BenchmarkBits_ToSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.ToSet()
+	}
+}
+
+func BenchmarkBits_Equal_WithNull(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsX_1000.Equal(nullBits)
+	}
+}
+
+func BenchmarkBits_Equal_SameObject(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Equal(randomBitsA_1000)
+	}
+}
+
+func BenchmarkBits_Equal_SameSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Equal(randomBitsB_1000)
+	}
+}
+
+func BenchmarkBits_Equal_DifferentSize(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Equal(randomBitsX_2000)
+	}
+}
+
+func BenchmarkBits_Len(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Len()
+	}
+}
+
+func BenchmarkBits_Copy_Null(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		nullBits.Copy()
+	}
+}
+
+func BenchmarkBits_Copy(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Copy()
+	}
+}
+
+func BenchmarkBits_Elements_Null(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		nullBits.Elements()
+	}
+}
+
+func BenchmarkBits_Elements(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Elements()
+	}
+}
+
+func BenchmarkBits_All(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for range randomBitsA_1000.All() {
+		}
+	}
+}
+
+func BenchmarkBits_NextSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for k, ok := randomBitsA_1000.NextSet(0); ok; k, ok = randomBitsA_1000.NextSet(k + 1) {
+		}
+	}
+}
+
+func BenchmarkBits_Contains_Null(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		nullBits.Contains(randomBitsKeys_1000[0], randomBitsKeys_1000...)
+	}
+}
+
+func BenchmarkBits_Contains_SameKeys(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Contains(randomBitsKeys_1000[0], randomBitsKeys_1000...)
+	}
+}
+
+func BenchmarkBits_Add_Null(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var s Bits
+		s.Add(randomBitsKeys_1000[0], randomBitsKeys_1000...)
+	}
+}
+
+func BenchmarkBits_Add_SameKeys(b *testing.B) {
+	s := randomBitsA_1000.Copy()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Add(randomBitsKeys_1000[0], randomBitsKeys_1000...)
+	}
+}
+
+func BenchmarkBits_Del_Null(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		nullBits.Del(randomBitsKeys_1000[0], randomBitsKeys_1000...)
+	}
+}
+
+func BenchmarkBits_Del_DifferentKeys(b *testing.B) {
+	s := randomBitsX_1000.Copy()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Del(randomBitsKeys_1000[0], randomBitsKeys_1000...)
+	}
+}
+
+func BenchmarkBits_Union_NullWith(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		nullBits.Union(randomBitsX_1000)
+	}
+}
+
+func BenchmarkBits_Union_SameObject(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Union(randomBitsA_1000)
+	}
+}
+
+func BenchmarkBits_Union_DifferentSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Union(randomBitsX_2000)
+	}
+}
+
+func BenchmarkBits_Intersection_NullWith(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		nullBits.Intersection(randomBitsX_1000)
+	}
+}
+
+func BenchmarkBits_Intersection_DifferentSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Intersection(randomBitsX_2000)
+	}
+}
+
+func BenchmarkBits_Difference_SameObject(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Difference(randomBitsA_1000)
+	}
+}
+
+func BenchmarkBits_Difference_DifferentSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.Difference(randomBitsX_2000)
+	}
+}
+
+func BenchmarkBits_SymmetricDifference_DifferentSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomBitsA_1000.SymmetricDifference(randomBitsX_2000)
+	}
+}
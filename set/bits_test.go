@@ -0,0 +1,171 @@
+package set
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var b1 = NewBits(0, 1, 2, 3, 4)
+var b2 = NewBits(3, 4, 5, 6, 7)
+var b3 = NewBits(6, 7, 8, 9, 10)
+
+// checkBits verifies that the bitset never ends with a zero word.
+func checkBits(t *testing.T, s *Bits) {
+	t.Helper()
+	if n := len(s.words); n > 0 {
+		assert.NotZero(t, s.words[n-1])
+	}
+}
+
+func TestNewBits(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected []int, s *Bits) {
+		checkBits(t, s)
+		assert.Equal(t, expected, s.Elements())
+		assert.Equal(t, len(expected), s.Len())
+	}
+
+	var zero Bits
+	t.Run("zero", func(t *testing.T) { check(t, []int{}, &zero) })
+	t.Run("none", func(t *testing.T) { check(t, []int{}, NewBits()) })
+	t.Run("{200,2,64,2,0}", func(t *testing.T) { check(t, []int{0, 2, 64, 200}, NewBits(200, 2, 64, 2, 0)) })
+	t.Run("copy", func(t *testing.T) { check(t, []int{0, 1, 2, 3, 4}, b1.Copy()) })
+	t.Run("negative", func(t *testing.T) {
+		assert.PanicsWithValue(t, "set: Bits key must not be negative", func() { NewBits(1, -1) })
+	})
+}
+
+func TestBits_AddDel(t *testing.T) {
+	t.Parallel()
+
+	var s Bits
+	s.Del(1)
+	s.Add(130, 1, 63, 64, 1)
+	checkBits(t, &s)
+	assert.Equal(t, []int{1, 63, 64, 130}, s.Elements())
+	assert.True(t, s.Contains(1, 63, 64, 130))
+	assert.False(t, s.Contains(1, 2))
+	assert.False(t, s.Contains(-1))
+	assert.False(t, s.Contains(1000))
+
+	s.Del(130, 500, -1)
+	checkBits(t, &s)
+	assert.Equal(t, []int{1, 63, 64}, s.Elements())
+	s.Del(1, 63, 64)
+	checkBits(t, &s)
+	assert.Zero(t, s.Len())
+	assert.True(t, s.Equal(&Bits{}))
+	assert.PanicsWithValue(t, "set: Bits key must not be negative", func() { s.Add(-1) })
+}
+
+func TestBits_Random(t *testing.T) {
+	t.Parallel()
+
+	var s Bits
+	model := New[int]()
+	for range 2000 {
+		k := rand.IntN(500)
+		if rand.IntN(3) == 0 {
+			s.Del(k)
+			model.Del(k)
+		} else {
+			s.Add(k)
+			model.Add(k)
+		}
+	}
+	checkBits(t, &s)
+	assert.Equal(t, model, s.ToSet())
+	assert.True(t, s.Equal(BitsFromSet(model)))
+	sorted := model.Elements()
+	slices.Sort(sorted)
+	assert.Equal(t, sorted, s.Elements())
+}
+
+func TestBits_NextSet(t *testing.T) {
+	t.Parallel()
+
+	s := NewBits(3, 63, 64, 300)
+	check := func(t *testing.T, i int, expected int, expectedOk bool) {
+		next, ok := s.NextSet(i)
+		assert.Equal(t, expectedOk, ok)
+		assert.Equal(t, expected, next)
+	}
+	t.Run("-5", func(t *testing.T) { check(t, -5, 3, true) })
+	t.Run("3", func(t *testing.T) { check(t, 3, 3, true) })
+	t.Run("4", func(t *testing.T) { check(t, 4, 63, true) })
+	t.Run("64", func(t *testing.T) { check(t, 64, 64, true) })
+	t.Run("65", func(t *testing.T) { check(t, 65, 300, true) })
+	t.Run("301", func(t *testing.T) { check(t, 301, 0, false) })
+	t.Run("1000", func(t *testing.T) { check(t, 1000, 0, false) })
+
+	var keys []int
+	for k, ok := s.NextSet(0); ok; k, ok = s.NextSet(k + 1) {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{3, 63, 64, 300}, keys)
+	_, ok := (&Bits{}).NextSet(0)
+	assert.False(t, ok)
+}
+
+func TestBits_All(t *testing.T) {
+	t.Parallel()
+
+	for k := range b1.All() {
+		assert.Equal(t, 0, k)
+		break
+	}
+	assert.Equal(t, []int{6, 7, 8, 9, 10}, slices.Collect(b3.All()))
+}
+
+func TestBits_Conversion(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, New(0, 1, 2, 3, 4), b1.ToSet())
+	assert.Equal(t, New[int](), (&Bits{}).ToSet())
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, BitsFromSet(New(4, 3, 2, 1, 0)).Elements())
+	assert.Equal(t, []int{}, BitsFromSet(nil).Elements())
+}
+
+func TestBits_Algebra(t *testing.T) {
+	t.Parallel()
+
+	null := &Bits{}
+	far := NewBits(0, 1000)
+	check := func(t *testing.T, expected []int, s *Bits) {
+		checkBits(t, s)
+		assert.Equal(t, expected, s.Elements())
+	}
+	t.Run("union", func(t *testing.T) {
+		check(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, b1.Union(b2))
+		check(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, b1.Union(b3, b2))
+		check(t, []int{0, 1, 2, 3, 4, 1000}, b1.Union(far))
+		check(t, []int{0, 1, 2, 3, 4}, null.Union(b1))
+		check(t, []int{0, 1, 2, 3, 4}, b1.Union(b1))
+	})
+	t.Run("intersection", func(t *testing.T) {
+		check(t, []int{3, 4}, b1.Intersection(b2))
+		check(t, []int{}, b1.Intersection(b2, b3))
+		check(t, []int{0}, far.Intersection(b1))
+		check(t, []int{}, null.Intersection(b1))
+		check(t, []int{0, 1, 2, 3, 4}, b1.Intersection(b1))
+	})
+	t.Run("difference", func(t *testing.T) {
+		check(t, []int{0, 1, 2}, b1.Difference(b2))
+		check(t, []int{5}, b2.Difference(b1, b3))
+		check(t, []int{1000}, far.Difference(b1))
+		check(t, []int{0, 1, 2, 3, 4}, b1.Difference(null))
+		check(t, []int{}, b1.Difference(b1))
+	})
+	t.Run("symmetric difference", func(t *testing.T) {
+		check(t, []int{0, 1, 2, 5, 6, 7}, b1.SymmetricDifference(b2))
+		check(t, []int{1, 2, 3, 4, 1000}, b1.SymmetricDifference(far))
+		check(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, b1.SymmetricDifference(b2, b3))
+		check(t, []int{1, 2, 3, 4, 1000}, b1.SymmetricDifference(b1, far))
+		check(t, []int{}, b1.SymmetricDifference(b1))
+	})
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, b1.Elements())
+}
@@ -0,0 +1,194 @@
+// bloom provides probabilistic sets, which can tell that a key is certainly not in a set
+// while taking only a few bits per key. They are meant as a cheap pre-check before looking
+// keys up in a large or remote set.Set.
+//
+// A Filter is a classic Bloom filter. A Cuckoo filter takes about as much memory for the
+// same false positive rate and also allows removing keys. A HyperLogLog doesn't answer
+// membership queries at all, it estimates the number of distinct keys instead.
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/bitstonks/go-adt/internal/splitmix"
+)
+
+// maxHashes is the largest number of hashes of a decoded filter. New never uses more than about
+// 1075, which is what the smallest positive false positive rate needs.
+const maxHashes = 2048
+
+// ErrIncompatible is returned when combining filters or sketches of different sizes.
+var ErrIncompatible = errors.New("bloom: filters are not compatible")
+
+// Filter is a Bloom filter. Contains reports all the keys that were added, but also other
+// keys with the false positive rate the filter was created for. Filters must not be copied
+// by value, use Copy() instead.
+type Filter[K any] struct {
+	words  []uint64
+	m      uint64 // number of bits
+	k      int    // number of hashes per key
+	hasher Hasher[K]
+}
+
+// New creates a Bloom filter for n keys with a false positive rate of p, which must be between
+// 0 and 1. The false positive rate grows when more than n keys are added.
+func New[K any](n int, p float64, hasher Hasher[K]) *Filter[K] {
+	if p <= 0 || p >= 1 {
+		panic("bloom: New() called with p out of range (0, 1)")
+	}
+	n = max(n, 1)
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return newFilter(uint64(m), max(int(k), 1), hasher)
+}
+
+func newFilter[K any](m uint64, k int, hasher Hasher[K]) *Filter[K] {
+	return &Filter[K]{
+		words:  make([]uint64, (m+63)/64),
+		m:      m,
+		k:      k,
+		hasher: hasher,
+	}
+}
+
+// Cap returns the number of bits in the filter.
+func (f *Filter[K]) Cap() int {
+	return int(f.m)
+}
+
+// Hashes returns the number of bits set for every key.
+func (f *Filter[K]) Hashes() int {
+	return f.k
+}
+
+// Copy creates a deep copy of the filter.
+func (f *Filter[K]) Copy() *Filter[K] {
+	g := newFilter(f.m, f.k, f.hasher)
+	copy(g.words, f.words)
+	return g
+}
+
+// Len estimates the number of keys added to the filter from the number of bits that are set.
+func (f *Filter[K]) Len() int {
+	set := 0
+	for _, w := range f.words {
+		set += bits.OnesCount64(w)
+	}
+	if uint64(set) == f.m {
+		return math.MaxInt
+	}
+	m := float64(f.m)
+	return int(math.Round(-m / float64(f.k) * math.Log(1-float64(set)/m)))
+}
+
+// Add inserts keys into the filter.
+func (f *Filter[K]) Add(key K, keys ...K) {
+	f.add(key)
+	for i := range keys {
+		f.add(keys[i])
+	}
+}
+
+// Contains checks if the filter may contain all of the given keys. It never returns false for
+// keys that were added.
+func (f *Filter[K]) Contains(key K, keys ...K) bool {
+	if !f.has(key) {
+		return false
+	}
+	for i := range keys {
+		if !f.has(keys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a filter that contains the keys of all the filters. The filters must have been
+// created with the same size, false positive rate and hasher, otherwise ErrIncompatible is returned.
+func (f *Filter[K]) Union(other *Filter[K], others ...*Filter[K]) (*Filter[K], error) {
+	g := f.Copy()
+	if err := g.update(other); err != nil {
+		return nil, err
+	}
+	for i := range others {
+		if err := g.update(others[i]); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// MarshalBinary encodes the filter without its hasher.
+func (f *Filter[K]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 12+8*len(f.words))
+	data = binary.LittleEndian.AppendUint64(data, f.m)
+	data = binary.LittleEndian.AppendUint32(data, uint32(f.k))
+	for _, w := range f.words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the filter with one encoded by MarshalBinary. The hasher of f is kept,
+// so f must have been created by New with the same hasher as the encoded filter.
+func (f *Filter[K]) UnmarshalBinary(data []byte) error {
+	if f.hasher == nil {
+		return errors.New("bloom: filter has no hasher, create it with New()")
+	}
+	if len(data) < 12 {
+		return errors.New("bloom: filter is truncated")
+	}
+	m, k := binary.LittleEndian.Uint64(data), binary.LittleEndian.Uint32(data[8:])
+	data = data[12:]
+	// Count the words of m bits without computing m+63, which overflows for huge m.
+	words := m/64 + min(m%64, 1)
+	if m == 0 || k == 0 || k > maxHashes || len(data)%8 != 0 || words != uint64(len(data)/8) {
+		return fmt.Errorf("bloom: invalid filter of %d bits and %d hashes in %d bytes", m, k, len(data))
+	}
+	f.words, f.m, f.k = make([]uint64, words), m, int(k)
+	for i := range f.words {
+		f.words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	return nil
+}
+
+// locations calls fn for the bits of key, until fn returns false. The bits are derived from
+// two halves of a single hash, which works as well as k independent hashes.
+func (f *Filter[K]) locations(key K, fn func(word int, mask uint64) bool) bool {
+	h := f.hasher.Hash(key)
+	h1, h2 := h, splitmix.Mix(h)|1
+	for i := 0; i < f.k; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		if !fn(int(bit/64), 1<<(bit%64)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Filter[K]) add(key K) {
+	f.locations(key, func(word int, mask uint64) bool {
+		f.words[word] |= mask
+		return true
+	})
+}
+
+func (f *Filter[K]) has(key K) bool {
+	return f.locations(key, func(word int, mask uint64) bool {
+		return f.words[word]&mask != 0
+	})
+}
+
+func (f *Filter[K]) update(other *Filter[K]) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrIncompatible
+	}
+	for i, w := range other.words {
+		f.words[i] |= w
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package bloom
+
+import (
+	"fmt"
+	"testing"
+)
+
+var benchmarkKeys = func() []string {
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprint(i)
+	}
+	return keys
+}()
+
+func BenchmarkFilter_Add(b *testing.B) {
+	f := New[string](len(benchmarkKeys), 0.01, StringHasher{})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Add(benchmarkKeys[i%len(benchmarkKeys)])
+	}
+}
+
+func BenchmarkFilter_Contains(b *testing.B) {
+	f := New[string](len(benchmarkKeys), 0.01, StringHasher{})
+	f.Add(benchmarkKeys[0], benchmarkKeys...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Contains(benchmarkKeys[i%len(benchmarkKeys)])
+	}
+}
+
+func BenchmarkCuckoo_AddDel(b *testing.B) {
+	c := NewCuckoo[string](len(benchmarkKeys), StringHasher{})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := benchmarkKeys[i%len(benchmarkKeys)]
+		c.Add(k)
+		c.Del(k)
+	}
+}
+
+func BenchmarkCuckoo_Contains(b *testing.B) {
+	c := NewCuckoo[string](len(benchmarkKeys), StringHasher{})
+	for _, k := range benchmarkKeys {
+		c.Add(k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Contains(benchmarkKeys[i%len(benchmarkKeys)])
+	}
+}
+
+func BenchmarkHyperLogLog_Add(b *testing.B) {
+	h := NewHyperLogLog[string](14, StringHasher{})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Add(benchmarkKeys[i%len(benchmarkKeys)])
+	}
+}
+
+func BenchmarkHyperLogLog_Count(b *testing.B) {
+	h := NewHyperLogLog[string](14, StringHasher{})
+	for i := 0; i < 100000; i++ {
+		h.Add(fmt.Sprint(i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Count()
+	}
+}
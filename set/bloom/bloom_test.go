@@ -0,0 +1,134 @@
+package bloom
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/bitstonks/go-adt/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randomKeys returns n distinct random keys.
+func randomKeys(n int) set.Set[string] {
+	keys := set.New[string]()
+	for len(keys) < n {
+		keys.Add(fmt.Sprint(rand.Uint64()))
+	}
+	return keys
+}
+
+// falsePositiveRate counts the keys that are not in added, but contains reports them anyway.
+func falsePositiveRate(added set.Set[string], contains func(string) bool) float64 {
+	fp, n := 0, 0
+	for n < 100000 {
+		k := fmt.Sprint(rand.Uint64())
+		if added.Contains(k) {
+			continue
+		}
+		n++
+		if contains(k) {
+			fp++
+		}
+	}
+	return float64(fp) / float64(n)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	f := New[string](1000, 0.01, StringHasher{})
+	assert.Equal(t, 9586, f.Cap())
+	assert.Equal(t, 7, f.Hashes())
+	assert.Zero(t, f.Len())
+	assert.PanicsWithValue(t, "bloom: New() called with p out of range (0, 1)", func() { New[string](1000, 0, StringHasher{}) })
+	assert.PanicsWithValue(t, "bloom: New() called with p out of range (0, 1)", func() { New[string](1000, 1, StringHasher{}) })
+	assert.Equal(t, 1, New[string](0, 0.5, StringHasher{}).Hashes())
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range []float64{0.1, 0.01, 0.001} {
+		t.Run(fmt.Sprint(p), func(t *testing.T) {
+			keys := randomKeys(10000)
+			f := New[string](len(keys), p, StringHasher{})
+			for k := range keys.All() {
+				f.Add(k)
+			}
+			// There are no false negatives.
+			for k := range keys.All() {
+				require.True(t, f.Contains(k))
+			}
+			assert.Less(t, falsePositiveRate(keys, func(k string) bool { return f.Contains(k) }), 1.5*p)
+			assert.InEpsilon(t, len(keys), f.Len(), 0.05)
+		})
+	}
+}
+
+func TestFilter_AddContains(t *testing.T) {
+	t.Parallel()
+
+	f := New[string](100, 0.001, StringHasher{})
+	assert.False(t, f.Contains("a"))
+	f.Add("a", "b", "c")
+	assert.True(t, f.Contains("a", "b", "c"))
+	assert.False(t, f.Contains("a", "d"))
+	assert.Equal(t, 3, f.Len())
+
+	g := f.Copy()
+	g.Add("d")
+	assert.True(t, g.Contains("d"))
+	assert.False(t, f.Contains("d"))
+}
+
+func TestFilter_Union(t *testing.T) {
+	t.Parallel()
+
+	a := New[string](100, 0.001, StringHasher{})
+	b := New[string](100, 0.001, StringHasher{})
+	c := New[string](100, 0.001, StringHasher{})
+	a.Add("a")
+	b.Add("b")
+	c.Add("c")
+	u, err := a.Union(b, c)
+	require.NoError(t, err)
+	assert.True(t, u.Contains("a", "b", "c"))
+	assert.False(t, a.Contains("b"))
+
+	_, err = a.Union(New[string](1000, 0.001, StringHasher{}))
+	assert.ErrorIs(t, err, ErrIncompatible)
+	_, err = a.Union(b, New[string](100, 0.01, StringHasher{}))
+	assert.ErrorIs(t, err, ErrIncompatible)
+}
+
+func TestFilter_Binary(t *testing.T) {
+	t.Parallel()
+
+	f := New[string](1000, 0.01, StringHasher{})
+	f.Add("a", "b", "c")
+	data, err := f.MarshalBinary()
+	require.NoError(t, err)
+
+	g := New[string](1, 0.5, StringHasher{})
+	require.NoError(t, g.UnmarshalBinary(data))
+	assert.Equal(t, f, g)
+	assert.True(t, g.Contains("a", "b", "c"))
+
+	var zero Filter[string]
+	assert.EqualError(t, zero.UnmarshalBinary(data), "bloom: filter has no hasher, create it with New()")
+	assert.EqualError(t, g.UnmarshalBinary(data[:5]), "bloom: filter is truncated")
+	assert.EqualError(t, g.UnmarshalBinary(data[:len(data)-1]), "bloom: invalid filter of 9586 bits and 7 hashes in 1199 bytes")
+	// The number of words must not overflow for huge numbers of bits.
+	huge := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 3, 0, 0, 0}
+	assert.EqualError(t, g.UnmarshalBinary(huge), "bloom: invalid filter of 18446744073709551615 bits and 3 hashes in 0 bytes")
+	// A huge number of hashes would make every Add and Contains take forever.
+	slow := append([]byte{64, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}, make([]byte, 8)...)
+	assert.EqualError(t, g.UnmarshalBinary(slow), "bloom: invalid filter of 64 bits and 4294967295 hashes in 8 bytes")
+	slow[8], slow[9], slow[10], slow[11] = 0, 8, 0, 0
+	assert.NoError(t, g.UnmarshalBinary(slow))
+	assert.Equal(t, maxHashes, g.Hashes())
+	require.NoError(t, g.UnmarshalBinary(data))
+	assert.True(t, g.Contains("a", "b", "c"))
+}
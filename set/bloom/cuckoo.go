@@ -0,0 +1,283 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+
+	"github.com/bitstonks/go-adt/internal/splitmix"
+)
+
+// ErrFull is returned when keys don't fit into a cuckoo filter.
+var ErrFull = errors.New("bloom: cuckoo filter is full")
+
+const (
+	// bucketSize is the number of fingerprints in a bucket.
+	bucketSize = 4
+	// maxKicks limits how many fingerprints are moved around to make room for a new one.
+	maxKicks = 500
+)
+
+// Cuckoo is a cuckoo filter. Like a Bloom filter, Contains reports all the keys that were added
+// and a few others, but keys can also be removed. It stores a 16-bit fingerprint of every key in
+// one of two buckets, so the false positive rate is at most 8/2^16, about 0.012%. Cuckoo filters
+// must not be copied by value, use Copy() instead.
+type Cuckoo[K any] struct {
+	buckets [][bucketSize]uint16
+	count   int
+	// victim is the last fingerprint that didn't fit, the filter is full while it's there.
+	victim struct {
+		index uint32
+		fp    uint16
+	}
+	hasher Hasher[K]
+}
+
+// NewCuckoo creates a cuckoo filter for up to n keys.
+func NewCuckoo[K any](n int, hasher Hasher[K]) *Cuckoo[K] {
+	// Buckets can be filled to about 95% before inserts start failing.
+	buckets := uint64(math.Ceil(float64(max(n, 1)) / bucketSize / 0.95))
+	return newCuckoo(1<<bits.Len64(buckets-1), hasher)
+}
+
+func newCuckoo[K any](buckets int, hasher Hasher[K]) *Cuckoo[K] {
+	return &Cuckoo[K]{
+		buckets: make([][bucketSize]uint16, buckets),
+		hasher:  hasher,
+	}
+}
+
+// Len returns the number of keys in the filter.
+func (c *Cuckoo[K]) Len() int {
+	return c.count
+}
+
+// Cap returns the number of fingerprints the filter has room for.
+func (c *Cuckoo[K]) Cap() int {
+	return len(c.buckets) * bucketSize
+}
+
+// Copy creates a deep copy of the filter.
+func (c *Cuckoo[K]) Copy() *Cuckoo[K] {
+	d := newCuckoo(len(c.buckets), c.hasher)
+	copy(d.buckets, c.buckets)
+	d.count = c.count
+	d.victim = c.victim
+	return d
+}
+
+// Add inserts key into the filter and reports whether it was added. It fails once the filter is
+// full. A key added several times is stored several times and has to be removed as many times.
+func (c *Cuckoo[K]) Add(key K) bool {
+	i, fp := c.locate(key)
+	return c.insert(i, fp)
+}
+
+// Contains checks if the filter may contain all of the given keys. It never returns false for
+// keys that were added.
+func (c *Cuckoo[K]) Contains(key K, keys ...K) bool {
+	if !c.has(key) {
+		return false
+	}
+	for i := range keys {
+		if !c.has(keys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Del removes key from the filter and reports whether it may have been there. Only keys that
+// were added can be removed, otherwise Del might remove another key with the same fingerprint.
+// When the filter is full, the fingerprint that didn't fit takes the freed slot if that is one
+// of its buckets, which makes room for the next Add. Otherwise it is moved around again and the
+// filter may stay full.
+func (c *Cuckoo[K]) Del(key K) bool {
+	i1, fp := c.locate(key)
+	if c.victim.fp == fp && (c.victim.index == i1 || c.victim.index == c.alt(i1, fp)) {
+		c.victim.fp = 0
+		c.count--
+		return true
+	}
+	for _, i := range [2]uint32{i1, c.alt(i1, fp)} {
+		for slot, f := range c.buckets[i] {
+			if f == fp {
+				c.buckets[i][slot] = 0
+				c.count--
+				c.reinsertVictim(i)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Union returns a filter that contains the keys of all the filters. The filters must have been
+// created with the same capacity and hasher, otherwise ErrIncompatible is returned. ErrFull is
+// returned if the keys don't fit into a single filter.
+func (c *Cuckoo[K]) Union(other *Cuckoo[K], others ...*Cuckoo[K]) (*Cuckoo[K], error) {
+	d := c.Copy()
+	if err := d.update(other); err != nil {
+		return nil, err
+	}
+	for i := range others {
+		if err := d.update(others[i]); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// MarshalBinary encodes the filter without its hasher.
+func (c *Cuckoo[K]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 18+2*bucketSize*len(c.buckets))
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(c.buckets)))
+	data = binary.LittleEndian.AppendUint64(data, uint64(c.count))
+	data = binary.LittleEndian.AppendUint32(data, c.victim.index)
+	data = binary.LittleEndian.AppendUint16(data, c.victim.fp)
+	for _, b := range c.buckets {
+		for _, fp := range b {
+			data = binary.LittleEndian.AppendUint16(data, fp)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the filter with one encoded by MarshalBinary. The hasher of c is kept,
+// so c must have been created by NewCuckoo with the same hasher as the encoded filter.
+func (c *Cuckoo[K]) UnmarshalBinary(data []byte) error {
+	if c.hasher == nil {
+		return errors.New("bloom: filter has no hasher, create it with NewCuckoo()")
+	}
+	if len(data) < 18 {
+		return errors.New("bloom: filter is truncated")
+	}
+	n := binary.LittleEndian.Uint32(data)
+	count := binary.LittleEndian.Uint64(data[4:])
+	index, victim := binary.LittleEndian.Uint32(data[12:]), binary.LittleEndian.Uint16(data[16:])
+	data = data[18:]
+	if n == 0 || n&(n-1) != 0 || uint64(len(data)) != uint64(n)*2*bucketSize || index >= n {
+		return fmt.Errorf("bloom: invalid cuckoo filter of %d buckets in %d bytes", n, len(data))
+	}
+	buckets := make([][bucketSize]uint16, n)
+	stored := uint64(0)
+	for i := range buckets {
+		for slot := range buckets[i] {
+			buckets[i][slot] = binary.LittleEndian.Uint16(data[2*(bucketSize*i+slot):])
+			if buckets[i][slot] != 0 {
+				stored++
+			}
+		}
+	}
+	if victim != 0 {
+		stored++
+	}
+	if stored != count {
+		return fmt.Errorf("bloom: cuckoo filter has %d keys instead of %d", stored, count)
+	}
+	c.buckets, c.count = buckets, int(count)
+	c.victim.index, c.victim.fp = index, victim
+	return nil
+}
+
+// locate returns the first bucket and the fingerprint of key. The fingerprint is never 0,
+// which marks empty slots.
+func (c *Cuckoo[K]) locate(key K) (uint32, uint16) {
+	h := c.hasher.Hash(key)
+	fp := uint16(h >> 48)
+	if fp == 0 {
+		fp = 1
+	}
+	return uint32(h) & uint32(len(c.buckets)-1), fp
+}
+
+// alt returns the other bucket of a fingerprint. Both buckets are the alt of each other,
+// so a fingerprint can be moved between them without knowing its key.
+func (c *Cuckoo[K]) alt(i uint32, fp uint16) uint32 {
+	return (i ^ uint32(splitmix.Mix(uint64(fp)))) & uint32(len(c.buckets)-1)
+}
+
+func (c *Cuckoo[K]) has(key K) bool {
+	i1, fp := c.locate(key)
+	i2 := c.alt(i1, fp)
+	if c.victim.fp == fp && (c.victim.index == i1 || c.victim.index == i2) {
+		return true
+	}
+	for slot := range bucketSize {
+		if c.buckets[i1][slot] == fp || c.buckets[i2][slot] == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// put stores the fingerprint in a free slot of bucket i, if there is one.
+func (c *Cuckoo[K]) put(i uint32, fp uint16) bool {
+	for slot, f := range c.buckets[i] {
+		if f == 0 {
+			c.buckets[i][slot] = fp
+			return true
+		}
+	}
+	return false
+}
+
+// insert stores the fingerprint in bucket i or its alt, moving other fingerprints to their alt
+// buckets to make room. The last fingerprint that doesn't fit becomes the victim.
+func (c *Cuckoo[K]) insert(i uint32, fp uint16) bool {
+	if c.victim.fp != 0 {
+		return false
+	}
+	c.count++
+	if c.put(i, fp) {
+		return true
+	}
+	if i = c.alt(i, fp); c.put(i, fp) {
+		return true
+	}
+	for range maxKicks {
+		slot := rand.IntN(bucketSize)
+		fp, c.buckets[i][slot] = c.buckets[i][slot], fp
+		if i = c.alt(i, fp); c.put(i, fp) {
+			return true
+		}
+	}
+	c.victim.index, c.victim.fp = i, fp
+	return true
+}
+
+// reinsertVictim tries to store the victim again after a slot in bucket free was emptied.
+func (c *Cuckoo[K]) reinsertVictim(free uint32) {
+	if c.victim.fp == 0 {
+		return
+	}
+	i, fp := c.victim.index, c.victim.fp
+	c.victim.fp = 0
+	if i == free || c.alt(i, fp) == free {
+		// The free slot is in one of the buckets of the victim, so it fits right away.
+		c.put(free, fp)
+		return
+	}
+	c.count--
+	c.insert(i, fp)
+}
+
+func (c *Cuckoo[K]) update(other *Cuckoo[K]) error {
+	if len(c.buckets) != len(other.buckets) {
+		return ErrIncompatible
+	}
+	for i, b := range other.buckets {
+		for _, fp := range b {
+			if fp != 0 && !c.insert(uint32(i), fp) {
+				return ErrFull
+			}
+		}
+	}
+	if fp := other.victim.fp; fp != 0 && !c.insert(other.victim.index, fp) {
+		return ErrFull
+	}
+	return nil
+}
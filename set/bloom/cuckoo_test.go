@@ -0,0 +1,167 @@
+package bloom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCuckoo(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 4, NewCuckoo[string](0, StringHasher{}).Cap())
+	assert.Equal(t, 4096, NewCuckoo[string](3000, StringHasher{}).Cap())
+	assert.Equal(t, 8192, NewCuckoo[string](4000, StringHasher{}).Cap())
+}
+
+func TestCuckoo(t *testing.T) {
+	t.Parallel()
+
+	keys := randomKeys(10000)
+	c := NewCuckoo[string](len(keys), StringHasher{})
+	for k := range keys.All() {
+		require.True(t, c.Add(k))
+	}
+	assert.Equal(t, len(keys), c.Len())
+	// There are no false negatives.
+	for k := range keys.All() {
+		require.True(t, c.Contains(k))
+	}
+	assert.Less(t, falsePositiveRate(keys, func(k string) bool { return c.Contains(k) }), 0.001)
+
+	// Removing half of the keys keeps the other half.
+	removed := 0
+	for k := range keys.All() {
+		if removed == len(keys)/2 {
+			break
+		}
+		require.True(t, c.Del(k))
+		keys.Del(k)
+		removed++
+	}
+	assert.Equal(t, len(keys), c.Len())
+	for k := range keys.All() {
+		require.True(t, c.Contains(k))
+	}
+}
+
+func TestCuckoo_AddDel(t *testing.T) {
+	t.Parallel()
+
+	c := NewCuckoo[string](100, StringHasher{})
+	assert.False(t, c.Contains("a"))
+	assert.False(t, c.Del("a"))
+	assert.True(t, c.Add("a"))
+	assert.True(t, c.Add("a"))
+	assert.True(t, c.Add("b"))
+	assert.True(t, c.Contains("a", "b"))
+	assert.Equal(t, 3, c.Len())
+	assert.True(t, c.Del("a"))
+	assert.True(t, c.Contains("a"))
+	assert.True(t, c.Del("a"))
+	assert.False(t, c.Contains("a"))
+	assert.True(t, c.Contains("b"))
+
+	d := c.Copy()
+	d.Del("b")
+	assert.True(t, c.Contains("b"))
+	assert.False(t, d.Contains("b"))
+}
+
+func TestCuckoo_Full(t *testing.T) {
+	t.Parallel()
+
+	c := NewCuckoo[string](100, StringHasher{})
+	var added []string
+	for i := 0; ; i++ {
+		k := fmt.Sprint(i)
+		if !c.Add(k) {
+			break
+		}
+		added = append(added, k)
+	}
+	assert.Equal(t, len(added), c.Len())
+	assert.LessOrEqual(t, c.Len(), c.Cap()+1)
+	assert.Greater(t, c.Len(), c.Cap()*9/10)
+	for _, k := range added {
+		require.True(t, c.Contains(k), k)
+	}
+	// Removing a key never removes any of the others.
+	require.True(t, c.Del(added[0]))
+	assert.Equal(t, len(added)-1, c.Len())
+	for _, k := range added[1:] {
+		require.True(t, c.Contains(k), k)
+	}
+}
+
+func TestCuckoo_FullDel(t *testing.T) {
+	t.Parallel()
+
+	// All the fingerprints of a filter with a single bucket share it, so removing any key frees
+	// a slot that the last fingerprint can take, which makes room for the next key.
+	c := newCuckoo[string](1, StringHasher{})
+	for i := range bucketSize + 1 {
+		require.True(t, c.Add(fmt.Sprint(i)))
+	}
+	assert.NotZero(t, c.victim.fp)
+	assert.False(t, c.Add("full"))
+	for i := range bucketSize + 1 {
+		require.True(t, c.Del(fmt.Sprint(i)))
+		assert.Zero(t, c.victim.fp)
+		assert.Equal(t, bucketSize, c.Len())
+		require.True(t, c.Add(fmt.Sprint(i)))
+		assert.Equal(t, bucketSize+1, c.Len())
+	}
+	for i := range bucketSize + 1 {
+		assert.True(t, c.Contains(fmt.Sprint(i)))
+	}
+}
+
+func TestCuckoo_Union(t *testing.T) {
+	t.Parallel()
+
+	a := NewCuckoo[string](100, StringHasher{})
+	b := NewCuckoo[string](100, StringHasher{})
+	c := NewCuckoo[string](100, StringHasher{})
+	a.Add("a")
+	b.Add("b")
+	c.Add("c")
+	u, err := a.Union(b, c)
+	require.NoError(t, err)
+	assert.True(t, u.Contains("a", "b", "c"))
+	assert.Equal(t, 3, u.Len())
+	assert.False(t, a.Contains("b"))
+	assert.True(t, u.Del("b"))
+
+	_, err = a.Union(NewCuckoo[string](1000, StringHasher{}))
+	assert.ErrorIs(t, err, ErrIncompatible)
+
+	full := NewCuckoo[string](100, StringHasher{})
+	for i := 0; full.Add(fmt.Sprint(i)); i++ {
+	}
+	_, err = full.Union(b)
+	assert.ErrorIs(t, err, ErrFull)
+}
+
+func TestCuckoo_Binary(t *testing.T) {
+	t.Parallel()
+
+	c := NewCuckoo[string](100, StringHasher{})
+	for i := 0; c.Add(fmt.Sprint(i)); i++ {
+	}
+	data, err := c.MarshalBinary()
+	require.NoError(t, err)
+
+	d := NewCuckoo[string](1, StringHasher{})
+	require.NoError(t, d.UnmarshalBinary(data))
+	assert.Equal(t, c, d)
+
+	var zero Cuckoo[string]
+	assert.EqualError(t, zero.UnmarshalBinary(data), "bloom: filter has no hasher, create it with NewCuckoo()")
+	assert.EqualError(t, d.UnmarshalBinary(data[:5]), "bloom: filter is truncated")
+	assert.EqualError(t, d.UnmarshalBinary(data[:len(data)-1]), "bloom: invalid cuckoo filter of 32 buckets in 255 bytes")
+	data[4]++
+	assert.EqualError(t, d.UnmarshalBinary(data), fmt.Sprintf("bloom: cuckoo filter has %d keys instead of %d", c.Len(), c.Len()+1))
+}
@@ -0,0 +1,74 @@
+package bloom
+
+import (
+	"math/rand/v2"
+
+	"github.com/bitstonks/go-adt/internal/splitmix"
+	"github.com/bitstonks/go-adt/set"
+)
+
+// Hasher hashes keys for the filters. Filters can only be combined with and decoded from
+// filters that use a hasher giving the same hashes. All the bits of the hash are used,
+// so they should be well mixed. Only StringHasher and BytesHasher give the same hashes in
+// every process, filters using any other hasher must not be stored or exchanged. That includes
+// the hashers of package set, which can be used here too but are random for every process.
+type Hasher[K any] interface {
+	Hash(key K) uint64
+}
+
+// HasherFunc turns a function into a Hasher.
+type HasherFunc[K any] func(key K) uint64
+
+// Hash returns f(key).
+func (f HasherFunc[K]) Hash(key K) uint64 {
+	return f(key)
+}
+
+// StringHasher hashes strings the same way in every process, so that filters of strings
+// can be stored and exchanged.
+type StringHasher struct{}
+
+// Hash returns the hash of key.
+func (StringHasher) Hash(key string) uint64 {
+	h := uint64(fnvOffset)
+	for i := 0; i < len(key); i++ {
+		h = (h ^ uint64(key[i])) * fnvPrime
+	}
+	return splitmix.Mix(h)
+}
+
+// BytesHasher hashes byte slices the same way in every process, so that filters of byte
+// slices can be stored and exchanged. A byte slice has the same hash as the same string.
+type BytesHasher struct{}
+
+// Hash returns the hash of key.
+func (BytesHasher) Hash(key []byte) uint64 {
+	h := uint64(fnvOffset)
+	for _, b := range key {
+		h = (h ^ uint64(b)) * fnvPrime
+	}
+	return splitmix.Mix(h)
+}
+
+// ComparableHasher hashes any comparable keys like set.KeyHasher does, mixed with a seed of its
+// own. The hashes depend on a random seed, so filters using different ComparableHashers are not
+// compatible, which also means that they can only be decoded in the same process that encoded them.
+type ComparableHasher[K comparable] struct {
+	seed uint64
+}
+
+// NewComparableHasher creates a hasher with a new random seed.
+func NewComparableHasher[K comparable]() ComparableHasher[K] {
+	return ComparableHasher[K]{seed: rand.Uint64()}
+}
+
+// Hash returns the hash of key.
+func (h ComparableHasher[K]) Hash(key K) uint64 {
+	return splitmix.Mix(set.KeyHasher[K]{}.Hash(key) ^ h.seed)
+}
+
+// 64-bit FNV-1a parameters.
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
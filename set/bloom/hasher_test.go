@@ -0,0 +1,35 @@
+package bloom
+
+import (
+	"testing"
+
+	"github.com/bitstonks/go-adt/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashers(t *testing.T) {
+	t.Parallel()
+
+	// The hashes of strings and byte slices never change, so that encoded filters stay valid.
+	assert.Equal(t, uint64(0x16fe05a1c75bcd0f), StringHasher{}.Hash("hello"))
+	assert.Equal(t, StringHasher{}.Hash("hello"), BytesHasher{}.Hash([]byte("hello")))
+	assert.NotEqual(t, StringHasher{}.Hash("hello"), StringHasher{}.Hash("hellp"))
+
+	type key struct {
+		a int
+		b string
+	}
+	h := NewComparableHasher[key]()
+	assert.Equal(t, h.Hash(key{1, "a"}), h.Hash(key{1, "a"}))
+	assert.NotEqual(t, h.Hash(key{1, "a"}), h.Hash(key{1, "b"}))
+
+	f := HasherFunc[int](func(k int) uint64 { return uint64(k) })
+	assert.Equal(t, uint64(42), f.Hash(42))
+
+	// The hashers of set.HashSet work for filters kept in memory too.
+	filter := New[string](100, 0.01, set.FoldHasher{})
+	filter.Add("Hello")
+	assert.True(t, filter.Contains("hELLO"))
+	var _ Hasher[[]byte] = set.ByteSliceHasher{}
+	var _ Hasher[key] = set.KeyHasher[key]{}
+}
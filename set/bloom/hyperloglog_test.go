@@ -0,0 +1,198 @@
+package bloom
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/bitstonks/go-adt/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// relativeError returns how far the count of h is off from the exact number of keys.
+func relativeError(h *HyperLogLog[string], keys set.Set[string]) float64 {
+	return math.Abs(float64(h.Count())-float64(len(keys))) / float64(len(keys))
+}
+
+func TestNewHyperLogLog(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 14, NewHyperLogLog[string](14, StringHasher{}).Precision())
+	assert.Equal(t, 0, NewHyperLogLog[string](14, StringHasher{}).Count())
+	assert.PanicsWithValue(t, "bloom: NewHyperLogLog() called with precision out of range [4, 18]", func() {
+		NewHyperLogLog[string](3, StringHasher{})
+	})
+	assert.PanicsWithValue(t, "bloom: NewHyperLogLog() called with precision out of range [4, 18]", func() {
+		NewHyperLogLog[string](19, StringHasher{})
+	})
+}
+
+func TestHyperLogLog_Count(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range []int{10, 14} {
+		h := NewHyperLogLog[string](p, StringHasher{})
+		keys := set.New[string]()
+		// Three standard errors. The keys and their hashes are fixed, so the test is deterministic.
+		bound := 3 * 1.04 / math.Sqrt(float64(int(1)<<p))
+		for _, n := range []int{10, 100, 1000, 10000, 100000} {
+			for len(keys) < n {
+				k := fmt.Sprint(len(keys))
+				keys.Add(k)
+				// Duplicates don't count.
+				h.Add(k, k)
+			}
+			if h.dense == nil {
+				// The sparse representation is almost exact.
+				assert.InDelta(t, len(keys), h.Count(), 1, "p=%d n=%d", p, n)
+			} else {
+				assert.Less(t, relativeError(h, keys), bound, "p=%d n=%d", p, n)
+			}
+		}
+		assert.NotNil(t, h.dense, "p=%d", p)
+	}
+}
+
+func TestHyperLogLog_Promotion(t *testing.T) {
+	t.Parallel()
+
+	// Registers converted from the sparse representation are the same as if the keys were
+	// added to dense registers in the first place.
+	keys := randomKeys(5000)
+	sparse := NewHyperLogLog[string](12, StringHasher{})
+	dense := NewHyperLogLog[string](12, StringHasher{})
+	dense.toDense()
+	for k := range keys.All() {
+		sparse.Add(k)
+		dense.Add(k)
+	}
+	require.NotNil(t, sparse.dense)
+	assert.Equal(t, dense.dense, sparse.dense)
+	assert.Equal(t, dense.Count(), sparse.Count())
+}
+
+func TestHyperLogLog_CountReadOnly(t *testing.T) {
+	t.Parallel()
+
+	for _, precision := range []int{4, 14} {
+		// With precision 4 sorting in the buffer would also make the sketch dense.
+		h := NewHyperLogLog[string](precision, StringHasher{})
+		h.Add("a", "b", "c", "d", "e", "f", "g", "h")
+		require.NotEmpty(t, h.buffer)
+		before := h.Copy()
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.InDelta(t, 8, h.Count(), 1)
+				_, err := h.MarshalBinary()
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, before, h)
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{100, 20000} {
+		keys1, keys2 := randomKeys(n), randomKeys(n/2)
+		h1 := NewHyperLogLog[string](12, StringHasher{})
+		h2 := NewHyperLogLog[string](12, StringHasher{})
+		for k := range keys1.All() {
+			h1.Add(k)
+		}
+		for k := range keys2.All() {
+			h2.Add(k)
+		}
+		// Keys that are in both sketches are only counted once.
+		for k := range keys1.All() {
+			h2.Add(k)
+			keys2.Add(k)
+			if len(keys2) == n {
+				break
+			}
+		}
+		union := set.Union(keys1, keys2)
+		all := NewHyperLogLog[string](12, StringHasher{})
+		for k := range union.All() {
+			all.Add(k)
+		}
+
+		// Sparse and dense sketches can be merged in any combination.
+		for _, pair := range [][2]*HyperLogLog[string]{{h1, h2}, {h2, h1}, {all, h1}, {h2, all}} {
+			h := pair[0].Copy()
+			require.NoError(t, h.Merge(pair[1]))
+			assert.Equal(t, all.Count(), h.Count(), "n=%d", n)
+		}
+	}
+
+	sparse := NewHyperLogLog[string](12, StringHasher{})
+	dense := NewHyperLogLog[string](12, StringHasher{})
+	sparse.Add("a")
+	for k := range randomKeys(5000).All() {
+		dense.Add(k)
+	}
+	require.Nil(t, sparse.dense)
+	require.NotNil(t, dense.dense)
+	require.NoError(t, sparse.Merge(dense))
+	dense.Add("a")
+	assert.Equal(t, dense.dense, sparse.dense)
+
+	assert.ErrorIs(t, sparse.Merge(NewHyperLogLog[string](13, StringHasher{})), ErrIncompatible)
+}
+
+func TestHyperLogLog_Binary(t *testing.T) {
+	t.Parallel()
+
+	h := NewHyperLogLog[string](10, StringHasher{})
+	h.Add("a", "b", "c")
+	data, err := h.MarshalBinary()
+	require.NoError(t, err)
+	assert.Len(t, data, 2+3*4)
+
+	g := NewHyperLogLog[string](4, StringHasher{})
+	require.NoError(t, g.UnmarshalBinary(data))
+	assert.Equal(t, 10, g.Precision())
+	assert.Equal(t, 3, g.Count())
+	again, err := g.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, data, again)
+
+	for k := range randomKeys(1000).All() {
+		h.Add(k)
+	}
+	data, err = h.MarshalBinary()
+	require.NoError(t, err)
+	assert.Len(t, data, 2+1024)
+	require.NoError(t, g.UnmarshalBinary(data))
+	assert.Equal(t, h, g)
+
+	var zero HyperLogLog[string]
+	assert.EqualError(t, zero.UnmarshalBinary(data), "bloom: sketch has no hasher, create it with NewHyperLogLog()")
+	assert.EqualError(t, g.UnmarshalBinary(data[:1]), "bloom: sketch is truncated")
+	assert.EqualError(t, g.UnmarshalBinary([]byte{19, 0}), "bloom: invalid sketch of precision 19 in mode 0")
+	assert.EqualError(t, g.UnmarshalBinary([]byte{10, 2}), "bloom: invalid sketch of precision 10 in mode 2")
+	assert.EqualError(t, g.UnmarshalBinary(data[:100]), "bloom: invalid dense sketch of 98 bytes")
+	data[2] = 56
+	assert.EqualError(t, g.UnmarshalBinary(data), "bloom: invalid sketch register 56")
+	assert.EqualError(t, g.UnmarshalBinary([]byte{10, 0, 1, 0, 0}), "bloom: invalid sparse sketch of 3 bytes")
+	assert.EqualError(t, g.UnmarshalBinary([]byte{10, 0, 0, 0, 0, 0}), "bloom: invalid sparse sketch entries")
+	assert.EqualError(t, g.UnmarshalBinary([]byte{10, 0, 1, 1, 0, 0, 1, 1, 0, 0}), "bloom: invalid sparse sketch entries")
+
+	// An index beyond the sparse precision would be out of range once the sketch is dense.
+	s := NewHyperLogLog[string](14, StringHasher{})
+	assert.EqualError(t, s.UnmarshalBinary([]byte{14, 0, 0x01, 0x00, 0x00, 0x80}), "bloom: invalid sparse sketch entries")
+	dense := NewHyperLogLog[string](14, StringHasher{})
+	for k := range randomKeys(10000).All() {
+		dense.Add(k)
+	}
+	require.NotNil(t, dense.dense)
+	require.NoError(t, s.Merge(dense))
+	assert.Equal(t, dense.dense, s.dense)
+}
@@ -0,0 +1,188 @@
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MarshalJSON encodes the set as a JSON array of its keys. The keys are sorted if their underlying
+// type is a string, integer or floating-point type, so the output is deterministic for such sets.
+func (s Set[Key]) MarshalJSON() ([]byte, error) {
+	keys := s.Elements()
+	sortKeys(keys)
+	return json.Marshal(keys)
+}
+
+// UnmarshalJSON replaces the contents of the set with the keys in a JSON array.
+func (s *Set[Key]) UnmarshalJSON(data []byte) error {
+	var keys []Key
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	*s = New(keys...)
+	return nil
+}
+
+// MarshalText encodes the set as a comma separated list of its keys, sorted if possible.
+// Keys have to be strings, numbers or booleans, or implement encoding.TextMarshaler. They must
+// not contain commas and must not be empty, since an empty text is the empty set.
+func (s Set[Key]) MarshalText() ([]byte, error) {
+	keys := s.Elements()
+	sortKeys(keys)
+	texts := make([]string, 0, len(keys))
+	for i := range keys {
+		text, err := keyToText(keys[i])
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(text, ",") {
+			return nil, fmt.Errorf("set: key %q contains a comma", text)
+		}
+		if text == "" {
+			return nil, errors.New("set: key is encoded as an empty text")
+		}
+		texts = append(texts, text)
+	}
+	return []byte(strings.Join(texts, ",")), nil
+}
+
+// UnmarshalText replaces the contents of the set with the keys in a comma separated list.
+// Keys have to be strings, numbers or booleans, or implement encoding.TextUnmarshaler.
+func (s *Set[Key]) UnmarshalText(text []byte) error {
+	resultset := make(Set[Key])
+	if len(text) > 0 {
+		for _, t := range strings.Split(string(text), ",") {
+			key, err := keyFromText[Key](t)
+			if err != nil {
+				return err
+			}
+			resultset[key] = struct{}{}
+		}
+	}
+	*s = resultset
+	return nil
+}
+
+// GobEncode encodes the keys of the set using encoding/gob.
+func (s Set[Key]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.Elements()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the contents of the set with the keys encoded by GobEncode.
+func (s *Set[Key]) GobDecode(data []byte) error {
+	var keys []Key
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&keys); err != nil {
+		return err
+	}
+	*s = New(keys...)
+	return nil
+}
+
+func keyToText[Key comparable](key Key) (string, error) {
+	if m, ok := any(&key).(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("set: key of type %T is not a string, number or boolean and not an encoding.TextMarshaler", key)
+}
+
+func keyFromText[Key comparable](text string) (key Key, err error) {
+	if u, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err = u.UnmarshalText([]byte(text))
+		return
+	}
+	v := reflect.ValueOf(&key).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+		return
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(text)
+		v.SetBool(b)
+		return
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(text, 10, v.Type().Bits())
+		v.SetInt(i)
+		return
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		u, err = strconv.ParseUint(text, 10, v.Type().Bits())
+		v.SetUint(u)
+		return
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(text, v.Type().Bits())
+		v.SetFloat(f)
+		return
+	}
+	return key, fmt.Errorf("set: key of type %T is not a string, number or boolean and not an encoding.TextUnmarshaler", key)
+}
+
+// sortKeys sorts keys in place if the underlying type of Key is ordered and leaves them as they are otherwise.
+func sortKeys[Key comparable](keys []Key) {
+	// The most common key types don't need reflection at all.
+	switch k := any(keys).(type) {
+	case []string:
+		slices.Sort(k)
+		return
+	case []int:
+		slices.Sort(k)
+		return
+	}
+
+	v := reflect.ValueOf(keys)
+	switch reflect.TypeFor[Key]().Kind() {
+	case reflect.String:
+		sortKeysBy(keys, func(i int) string { return v.Index(i).String() })
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sortKeysBy(keys, func(i int) int64 { return v.Index(i).Int() })
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sortKeysBy(keys, func(i int) uint64 { return v.Index(i).Uint() })
+	case reflect.Float32, reflect.Float64:
+		sortKeysBy(keys, func(i int) float64 { return v.Index(i).Float() })
+	}
+}
+
+// sortKeysBy sorts keys by the values of their underlying type, which are read only once for every key.
+func sortKeysBy[Key comparable, V cmp.Ordered](keys []Key, value func(i int) V) {
+	type entry struct {
+		value V
+		key   Key
+	}
+	entries := make([]entry, len(keys))
+	for i := range keys {
+		entries[i] = entry{value: value(i), key: keys[i]}
+	}
+	slices.SortFunc(entries, func(a, b entry) int { return cmp.Compare(a.value, b.value) })
+	for i := range entries {
+		keys[i] = entries[i].key
+	}
+}
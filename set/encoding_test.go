@@ -0,0 +1,184 @@
+package set
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tag string
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected string, s any) {
+		data, err := json.Marshal(s)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+	t.Run("nil", func(t *testing.T) { check(t, "[]", snil) })
+	t.Run("null", func(t *testing.T) { check(t, "[]", null) })
+	t.Run("s1", func(t *testing.T) { check(t, "[0,1,2,3,4]", s1) })
+	t.Run("s3", func(t *testing.T) { check(t, "[6,7,8,9,10]", s3) })
+	t.Run("negative", func(t *testing.T) { check(t, "[-2,-1,3]", New(3, -1, -2)) })
+	t.Run("uint", func(t *testing.T) { check(t, "[1,2,300]", New[uint16](300, 2, 1)) })
+	t.Run("float", func(t *testing.T) { check(t, "[-1.5,0.5,2]", New(2, 0.5, -1.5)) })
+	t.Run("string", func(t *testing.T) { check(t, `["a","b","c"]`, New("c", "a", "b")) })
+	t.Run("named", func(t *testing.T) { check(t, `["x","y"]`, New[tag]("y", "x")) })
+	t.Run("field", func(t *testing.T) {
+		check(t, `{"Tags":["x","y"]}`, struct{ Tags Set[tag] }{New[tag]("y", "x")})
+	})
+	t.Run("unordered", func(t *testing.T) {
+		data, err := json.Marshal(New(struct{ A int }{1}, struct{ A int }{2}))
+		assert.NoError(t, err)
+		assert.Contains(t, []string{`[{"A":1},{"A":2}]`, `[{"A":2},{"A":1}]`}, string(data))
+	})
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected Set[E], data string) {
+		s := New[E](42)
+		assert.NoError(t, json.Unmarshal([]byte(data), &s))
+		assert.Equal(t, expected, s)
+	}
+	t.Run("null", func(t *testing.T) { check(t, null, "null") })
+	t.Run("[]", func(t *testing.T) { check(t, null, "[]") })
+	t.Run("s1", func(t *testing.T) { check(t, s1, "[4,3,2,1,0,0]") })
+	t.Run("invalid", func(t *testing.T) {
+		var s Set[E]
+		assert.Error(t, json.Unmarshal([]byte(`{"1":{}}`), &s))
+	})
+	t.Run("field", func(t *testing.T) {
+		var v struct{ Tags Set[tag] }
+		assert.NoError(t, json.Unmarshal([]byte(`{"Tags":["x","y"]}`), &v))
+		assert.Equal(t, New[tag]("x", "y"), v.Tags)
+	})
+}
+
+func TestMarshalText(t *testing.T) {
+	t.Parallel()
+
+	text, err := New[tag]("c", "a", "b").MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "a,b,c", string(text))
+
+	text, err = New[tag]().MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "", string(text))
+
+	text, err = New(netip.MustParseAddr("10.0.0.1")).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", string(text))
+
+	_, err = New("a,b").MarshalText()
+	assert.Error(t, err)
+	// An empty key would be decoded as the empty set.
+	_, err = New("").MarshalText()
+	assert.EqualError(t, err, "set: key is encoded as an empty text")
+	_, err = New[tag]("a", "").MarshalText()
+	assert.Error(t, err)
+	_, err = New(struct{ x int }{1}).MarshalText()
+	assert.EqualError(t, err, "set: key of type struct { x int } is not a string, number or boolean and not an encoding.TextMarshaler")
+
+	text, err = s1.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "0,1,2,3,4", string(text))
+	text, err = New[int8](-3, 2).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "-3,2", string(text))
+	text, err = New[uint16](7).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "7", string(text))
+	text, err = New[float32](0.1, -2.5).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "-2.5,0.1", string(text))
+	text, err = New(true).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "true", string(text))
+}
+
+func TestUnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	var s Set[tag]
+	assert.NoError(t, s.UnmarshalText([]byte("b,a,b")))
+	assert.Equal(t, New[tag]("a", "b"), s)
+	assert.NoError(t, s.UnmarshalText(nil))
+	assert.Equal(t, New[tag](), s)
+
+	var addrs Set[netip.Addr]
+	assert.NoError(t, addrs.UnmarshalText([]byte("10.0.0.1,::1")))
+	assert.Equal(t, New(netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("::1")), addrs)
+	assert.Error(t, addrs.UnmarshalText([]byte("10.0.0.1,nope")))
+
+	var ints Set[E]
+	assert.NoError(t, ints.UnmarshalText([]byte("3,-1,3")))
+	assert.Equal(t, New[E](-1, 3), ints)
+	assert.Error(t, ints.UnmarshalText([]byte("1,x")))
+	var small Set[int8]
+	assert.Error(t, small.UnmarshalText([]byte("128")))
+	var uints Set[uint]
+	assert.Error(t, uints.UnmarshalText([]byte("-1")))
+	var floats Set[float32]
+	assert.NoError(t, floats.UnmarshalText([]byte("0.1,-2.5")))
+	assert.Equal(t, New[float32](0.1, -2.5), floats)
+	var bools Set[bool]
+	assert.NoError(t, bools.UnmarshalText([]byte("true,false")))
+	assert.Equal(t, New(true, false), bools)
+
+	var points Set[struct{ x int }]
+	assert.Error(t, points.UnmarshalText([]byte("1")))
+}
+
+// Encoders that prefer encoding.TextMarshaler must still be able to encode sets of numbers.
+func TestTextMarshalerJSON(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(map[string]Set[int]{"a": New(2, 1), "b": nil})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":[1,2],"b":[]}`, string(data))
+	var decoded map[string]Set[int]
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, map[string]Set[int]{"a": New(1, 2), "b": New[int]()}, decoded)
+
+	data, err = json.Marshal(New(3, 1, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2,3]`, string(data))
+	for _, s := range []any{New(3, 1, 2), New[uint8](1), New(1.5), New(false)} {
+		_, err := s.(encoding.TextMarshaler).MarshalText()
+		assert.NoError(t, err, s)
+	}
+}
+
+func TestGob(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, s Set[E]) {
+		var buf bytes.Buffer
+		assert.NoError(t, gob.NewEncoder(&buf).Encode(s))
+		decoded := New[E](42)
+		assert.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+		assert.True(t, Equal(s, decoded))
+	}
+	t.Run("null", func(t *testing.T) { check(t, null) })
+	t.Run("s1", func(t *testing.T) { check(t, s1) })
+	t.Run("invalid", func(t *testing.T) {
+		var s Set[E]
+		assert.Error(t, s.GobDecode([]byte("garbage")))
+	})
+	t.Run("strings", func(t *testing.T) {
+		data, err := New("a", strings.Repeat("b", 100)).GobEncode()
+		assert.NoError(t, err)
+		var s Set[string]
+		assert.NoError(t, s.GobDecode(data))
+		assert.Equal(t, New("a", strings.Repeat("b", 100)), s)
+	})
+}
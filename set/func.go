@@ -0,0 +1,133 @@
+package set
+
+// Filter returns a new set with the keys of s for which keep returns true.
+func Filter[Key comparable](s Set[Key], keep func(Key) bool) Set[Key] {
+	// An empty set contains no keys.
+	if len(s) == 0 {
+		return make(Set[Key])
+	}
+
+	resultset := make(Set[Key])
+	for k := range s {
+		if keep(k) {
+			resultset[k] = struct{}{}
+		}
+	}
+	return resultset
+}
+
+// Map returns a new set with the results of f for all the keys of s. The result can be smaller
+// than s if f returns the same value for several keys.
+func Map[Key, R comparable](s Set[Key], f func(Key) R) Set[R] {
+	// An empty set contains no keys.
+	if len(s) == 0 {
+		return make(Set[R])
+	}
+
+	resultset := make(Set[R], len(s))
+	for k := range s {
+		resultset[f(k)] = struct{}{}
+	}
+	return resultset
+}
+
+// Partition splits s into the keys for which pred returns true and the ones for which it
+// returns false. Neither of the returned sets is nil.
+func Partition[Key comparable](s Set[Key], pred func(Key) bool) (yes, no Set[Key]) {
+	// An empty set contains no keys.
+	if len(s) == 0 {
+		return make(Set[Key]), make(Set[Key])
+	}
+
+	yes, no = make(Set[Key]), make(Set[Key])
+	for k := range s {
+		if pred(k) {
+			yes[k] = struct{}{}
+		} else {
+			no[k] = struct{}{}
+		}
+	}
+	return yes, no
+}
+
+// GroupBy splits s into sets of keys for which group returns the same value.
+// There are no empty sets in the result.
+func GroupBy[Key, G comparable](s Set[Key], group func(Key) G) map[G]Set[Key] {
+	// An empty set contains no keys.
+	if len(s) == 0 {
+		return make(map[G]Set[Key])
+	}
+
+	groups := make(map[G]Set[Key])
+	for k := range s {
+		g := group(k)
+		if groups[g] == nil {
+			groups[g] = make(Set[Key])
+		}
+		groups[g][k] = struct{}{}
+	}
+	return groups
+}
+
+// Any checks if pred returns true for any of the keys of s. It is false for an empty set.
+func Any[Key comparable](s Set[Key], pred func(Key) bool) bool {
+	for k := range s {
+		if pred(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// Every checks if pred returns true for all the keys of s. It is true for an empty set.
+func Every[Key comparable](s Set[Key], pred func(Key) bool) bool {
+	for k := range s {
+		if !pred(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reduce calls f for every key of s in no particular order, passing the result of the previous
+// call, starting with initial. It returns the result of the last call, or initial for an empty set.
+func Reduce[Key comparable, R any](s Set[Key], initial R, f func(R, Key) R) R {
+	// An empty set contains no keys.
+	if len(s) == 0 {
+		return initial
+	}
+
+	result := initial
+	for k := range s {
+		result = f(result, k)
+	}
+	return result
+}
+
+// RetainFunc is like Filter, but modifies the set in place.
+func (s Set[Key]) RetainFunc(keep func(Key) bool) {
+	// An empty set contains no keys.
+	if len(s) == 0 {
+		return
+	}
+
+	for k := range s {
+		if !keep(k) {
+			delete(s, k)
+		}
+	}
+}
+
+// DeleteFunc removes the keys for which del returns true from the set.
+func (s Set[Key]) DeleteFunc(del func(Key) bool) {
+	// An empty set contains no keys.
+	if len(s) == 0 {
+		return
+	}
+
+	for k := range s {
+		if del(k) {
+			delete(s, k)
+		}
+	}
+}
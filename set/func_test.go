@@ -0,0 +1,97 @@
+package set
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func even(k E) bool { return k%2 == 0 }
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected Set[E], s Set[E], keep func(E) bool) {
+		assert.Equal(t, expected, Filter(s, keep))
+
+		c := s.Copy()
+		c.RetainFunc(keep)
+		assert.Equal(t, expected, c)
+
+		c = s.Copy()
+		c.DeleteFunc(func(k E) bool { return !keep(k) })
+		assert.Equal(t, expected, c)
+	}
+
+	t.Run("nil", func(t *testing.T) { check(t, null, snil, even) })
+	t.Run("null", func(t *testing.T) { check(t, null, null, even) })
+	t.Run("s1,even", func(t *testing.T) { check(t, New[E](0, 2, 4), s1, even) })
+	t.Run("s2,even", func(t *testing.T) { check(t, New[E](4, 6), s2, even) })
+	t.Run("s1,all", func(t *testing.T) { check(t, s1, s1, func(E) bool { return true }) })
+	t.Run("s1,none", func(t *testing.T) { check(t, null, s1, func(E) bool { return false }) })
+	t.Run("nonnil", func(t *testing.T) { assert.NotNil(t, Filter(snil, even)) })
+}
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, New[string](), Map(snil, strconv.Itoa))
+	assert.Equal(t, New[string](), Map(null, strconv.Itoa))
+	assert.Equal(t, New("0", "1", "2", "3", "4"), Map(s1, strconv.Itoa))
+	// Keys mapped to the same value are merged.
+	assert.Equal(t, New(true, false), Map(s1, even))
+	assert.Equal(t, New(true), Map(New[E](0, 2), even))
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expectedYes, expectedNo Set[E], s Set[E]) {
+		yes, no := Partition(s, even)
+		assert.Equal(t, expectedYes, yes)
+		assert.Equal(t, expectedNo, no)
+	}
+
+	t.Run("nil", func(t *testing.T) { check(t, null, null, snil) })
+	t.Run("null", func(t *testing.T) { check(t, null, null, null) })
+	t.Run("s1", func(t *testing.T) { check(t, New[E](0, 2, 4), New[E](1, 3), s1) })
+	t.Run("s3", func(t *testing.T) { check(t, New[E](6, 8, 10), New[E](7, 9), s3) })
+	t.Run("evens", func(t *testing.T) { check(t, New[E](0, 2), null, New[E](0, 2)) })
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	mod3 := func(k E) int { return k % 3 }
+	assert.Equal(t, map[int]Set[E]{}, GroupBy(snil, mod3))
+	assert.Equal(t, map[int]Set[E]{}, GroupBy(null, mod3))
+	assert.Equal(t, map[int]Set[E]{0: New[E](0, 3), 1: New[E](1, 4), 2: New[E](2)}, GroupBy(s1, mod3))
+	assert.Equal(t, map[bool]Set[E]{true: New[E](6, 8, 10), false: New[E](7, 9)}, GroupBy(s3, even))
+}
+
+func TestAnyEvery(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expectedAny, expectedEvery bool, s Set[E]) {
+		assert.Equal(t, expectedAny, Any(s, even))
+		assert.Equal(t, expectedEvery, Every(s, even))
+	}
+
+	t.Run("nil", func(t *testing.T) { check(t, false, true, snil) })
+	t.Run("null", func(t *testing.T) { check(t, false, true, null) })
+	t.Run("s1", func(t *testing.T) { check(t, true, false, s1) })
+	t.Run("evens", func(t *testing.T) { check(t, true, true, New[E](0, 2)) })
+	t.Run("odds", func(t *testing.T) { check(t, false, false, New[E](1, 3)) })
+}
+
+func TestReduce(t *testing.T) {
+	t.Parallel()
+
+	sum := func(acc int, k E) int { return acc + k }
+	assert.Equal(t, 7, Reduce(snil, 7, sum))
+	assert.Equal(t, 7, Reduce(null, 7, sum))
+	assert.Equal(t, 10, Reduce(s1, 0, sum))
+	assert.Equal(t, 40, Reduce(s3, 0, sum))
+	assert.Equal(t, 5, Reduce(s2, 0, func(n int, _ E) int { return n + 1 }))
+}
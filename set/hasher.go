@@ -0,0 +1,162 @@
+package set
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// hashSeed is shared by everything in the package that hashes keys: the built-in hashers need
+// no initialisation, persistent sets built independently of each other have the same shape and
+// can be compared quickly, and any two MinHash sketches can be compared.
+var hashSeed = maphash.MakeSeed()
+
+// Hasher defines how keys of a HashSet are hashed and compared. Keys that are equal must have
+// the same hash. Since it has a Hash method, any Hasher can also be used as a bloom.Hasher.
+// The built-in hashers of this package give different hashes in every process, so their hashes
+// must never be stored or exchanged, not even inside encoded bloom filters. The hashers of
+// package bloom are the ones that are stable across processes.
+type Hasher[K any] interface {
+	Hash(key K) uint64
+	Equal(a, b K) bool
+}
+
+// ByteSliceHasher hashes and compares byte slices by their contents. The hashes are random for
+// every process, unlike the ones of bloom.BytesHasher.
+type ByteSliceHasher struct{}
+
+// Hash returns the hash of key.
+func (ByteSliceHasher) Hash(key []byte) uint64 {
+	return maphash.Bytes(hashSeed, key)
+}
+
+// Equal checks if a and b have the same contents.
+func (ByteSliceHasher) Equal(a, b []byte) bool {
+	return bytes.Equal(a, b)
+}
+
+// FoldHasher hashes and compares strings ignoring case, as defined by strings.EqualFold.
+// The hashes are random for every process.
+type FoldHasher struct{}
+
+// Hash returns the hash of key, which is the same for all the case variants of key.
+func (FoldHasher) Hash(key string) uint64 {
+	var h maphash.Hash
+	h.SetSeed(hashSeed)
+	var buf [utf8.UTFMax]byte
+	for _, r := range key {
+		// Hash the smallest rune that folds to r, which is the same for all of them.
+		folded := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			folded = min(folded, f)
+		}
+		n := utf8.EncodeRune(buf[:], folded)
+		h.Write(buf[:n])
+	}
+	return h.Sum64()
+}
+
+// Equal checks if a and b are equal ignoring case.
+func (FoldHasher) Equal(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// KeyHasher hashes and compares comparable keys like Set does, using hash/maphash.
+// The hashes are random for every process.
+type KeyHasher[K comparable] struct{}
+
+// Hash returns the hash of key.
+func (KeyHasher[K]) Hash(key K) uint64 {
+	return hashKey(key)
+}
+
+// Equal checks if a == b.
+func (KeyHasher[K]) Equal(a, b K) bool {
+	return a == b
+}
+
+// hashKeyReflect hashes a comparable key like maphash.Comparable, which only exists since Go 1.24.
+func hashKeyReflect[K comparable](key K) uint64 {
+	// The most common key types don't need reflection at all.
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(hashSeed, k)
+	case int:
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		return maphash.Bytes(hashSeed, buf[:])
+	}
+
+	var h maphash.Hash
+	h.SetSeed(hashSeed)
+	writeKey(&h, reflect.ValueOf(&key).Elem())
+	return h.Sum64()
+}
+
+// writeKey writes the value of a comparable key to h, so that keys that are == write the same
+// bytes. It panics if the key holds an interface with a value that is not comparable, just like
+// comparing it would.
+func writeKey(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	writeUint := func(x uint64) {
+		binary.LittleEndian.PutUint64(buf[:], x)
+		h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		// -0 == +0, and NaNs are never equal to anything, so any hash will do for them.
+		if f == 0 {
+			f = 0
+		}
+		writeUint(math.Float64bits(f))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(real(v.Complex()))
+		writeFloat(imag(v.Complex()))
+	case reflect.String:
+		// The length keeps the strings of neighbouring fields apart.
+		writeUint(uint64(v.Len()))
+		h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Array:
+		for i := range v.Len() {
+			writeKey(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			// Blank fields are ignored by ==.
+			if v.Type().Field(i).Name != "_" {
+				writeKey(h, v.Field(i))
+			}
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+			return
+		}
+		// Values of different types are never equal, but they may collide.
+		h.WriteByte(1)
+		writeKey(h, v.Elem())
+	default:
+		panic("set: hash of unhashable type " + v.Type().String())
+	}
+}
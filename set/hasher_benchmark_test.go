@@ -0,0 +1,43 @@
+package set
+
+import (
+	"strconv"
+	"testing"
+)
+
+func benchmarkHashKey[K comparable](b *testing.B, keys []K) {
+	b.Run("hashKey", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			hashKey(keys[i%len(keys)])
+		}
+	})
+	b.Run("hashKeyReflect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			hashKeyReflect(keys[i%len(keys)])
+		}
+	})
+}
+
+func BenchmarkHashKey_Int64(b *testing.B) {
+	benchmarkHashKey(b, randomKeys_1000)
+}
+
+func BenchmarkHashKey_String(b *testing.B) {
+	keys := make([]string, len(randomKeys_1000))
+	for i, k := range randomKeys_1000 {
+		keys[i] = strconv.FormatInt(k, 10)
+	}
+	benchmarkHashKey(b, keys)
+}
+
+func BenchmarkHashKey_Struct(b *testing.B) {
+	type key struct {
+		id   int64
+		name string
+	}
+	keys := make([]key, len(randomKeys_1000))
+	for i, k := range randomKeys_1000 {
+		keys[i] = key{k, strconv.FormatInt(k, 10)}
+	}
+	benchmarkHashKey(b, keys)
+}
@@ -0,0 +1,84 @@
+package set
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashers(t *testing.T) {
+	t.Parallel()
+
+	b := ByteSliceHasher{}
+	assert.Equal(t, b.Hash([]byte("abc")), b.Hash([]byte{'a', 'b', 'c'}))
+	assert.NotEqual(t, b.Hash([]byte("abc")), b.Hash([]byte("abd")))
+	assert.True(t, b.Equal([]byte("abc"), []byte("abc")))
+	assert.True(t, b.Equal(nil, []byte{}))
+	assert.False(t, b.Equal([]byte("abc"), []byte("ab")))
+
+	f := FoldHasher{}
+	for _, pair := range [][2]string{{"Hello", "hELLO"}, {"straße", "STRAßE"}, {"Σίσυφος", "ΣΊΣΥΦΟΣ"}, {"K", "K"}, {"", ""}} {
+		assert.True(t, f.Equal(pair[0], pair[1]), pair)
+		assert.Equal(t, f.Hash(pair[0]), f.Hash(pair[1]), pair)
+	}
+	assert.False(t, f.Equal("Hello", "Help"))
+	assert.NotEqual(t, f.Hash("Hello"), f.Hash("Help"))
+
+	type key struct {
+		a int
+		b string
+	}
+	c := KeyHasher[key]{}
+	assert.Equal(t, c.Hash(key{1, "a"}), c.Hash(key{1, "a"}))
+	assert.NotEqual(t, c.Hash(key{1, "a"}), c.Hash(key{1, "b"}))
+	assert.True(t, c.Equal(key{1, "a"}, key{1, "a"}))
+	assert.False(t, c.Equal(key{1, "a"}, key{2, "a"}))
+}
+
+func TestHashKey(t *testing.T) {
+	t.Parallel()
+
+	type tag string
+	type key struct {
+		a  any
+		b  [2]float64
+		p  *int
+		_  int
+		s1 string
+		s2 string
+	}
+	x, y := 1, 1
+	same := [][2]any{
+		{key{a: 1, b: [2]float64{0, 1}, p: &x, s1: "a"}, key{a: 1, b: [2]float64{math.Copysign(0, -1), 1}, p: &x, s1: "a"}},
+		{key{a: tag("a")}, key{a: tag("a")}},
+		{key{}, key{}},
+		{"abc", "ab" + string('c')},
+		{tag("abc"), tag("abc")},
+		{complex(0, 1), complex(math.Copysign(0, -1), 1)},
+	}
+	different := [][2]any{
+		{key{a: 1}, key{a: 2}},
+		{key{a: 1}, key{}},
+		{key{p: &x}, key{p: &y}},
+		{key{s1: "ab", s2: "c"}, key{s1: "a", s2: "bc"}},
+		{key{b: [2]float64{1, 2}}, key{b: [2]float64{2, 1}}},
+		{1, 2},
+	}
+	// hashKeyReflect is only used before Go 1.24, but it has to work the same as hashKey.
+	for name, hash := range map[string]func(any) uint64{"hashKey": hashKey[any], "hashKeyReflect": hashKeyReflect[any]} {
+		t.Run(name, func(t *testing.T) {
+			for _, pair := range same {
+				assert.Equal(t, pair[0], pair[1])
+				assert.Equal(t, hash(pair[0]), hash(pair[1]), pair)
+			}
+			for _, pair := range different {
+				assert.NotEqual(t, hash(pair[0]), hash(pair[1]), pair)
+			}
+			assert.Panics(t, func() { hash([]int{1}) })
+		})
+	}
+	assert.Equal(t, hashKeyReflect(1), hashKeyReflect(1))
+	assert.Equal(t, hashKeyReflect("abc"), hashKeyReflect("ab"+string('c')))
+	assert.PanicsWithValue(t, "set: hash of unhashable type []int", func() { hashKeyReflect[any]([]int{1}) })
+}
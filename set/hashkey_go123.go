@@ -0,0 +1,10 @@
+//go:build !go1.24
+
+package set
+
+// hashKey returns the hash of a comparable key, which is random for every process. Go 1.23 has
+// no maphash.Comparable, so keys other than strings and ints are hashed by walking them with
+// reflect, which is several times slower, see BenchmarkHashKey.
+func hashKey[K comparable](key K) uint64 {
+	return hashKeyReflect(key)
+}
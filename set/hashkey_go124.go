@@ -0,0 +1,10 @@
+//go:build go1.24
+
+package set
+
+import "hash/maphash"
+
+// hashKey returns the hash of a comparable key, which is random for every process.
+func hashKey[K comparable](key K) uint64 {
+	return maphash.Comparable(hashSeed, key)
+}
@@ -0,0 +1,294 @@
+package set
+
+import (
+	"iter"
+	"slices"
+)
+
+// HashSet is a set of keys that don't have to be comparable, like slices or case-insensitive
+// strings, which are hashed and compared by a Hasher. Operations on several sets require all of
+// them to hash and compare the keys the same way. The zero value is an empty set without a
+// Hasher, which can be read, but adding keys to it, also through Update or Union, panics. Sets
+// should be created with NewHashSet. HashSets must not be copied by value, use Copy() instead.
+type HashSet[K any] struct {
+	// buckets holds the keys with the same hash, there are no empty buckets.
+	buckets map[uint64][]K
+	len     int
+	hasher  Hasher[K]
+}
+
+// NewHashSet creates a new set that uses hasher and contains all the given keys.
+func NewHashSet[K any](hasher Hasher[K], keys ...K) *HashSet[K] {
+	s := &HashSet[K]{buckets: make(map[uint64][]K, len(keys)), hasher: hasher}
+	for i := range keys {
+		s.add(keys[i])
+	}
+	return s
+}
+
+// Len returns the number of keys in the set.
+func (s *HashSet[K]) Len() int {
+	return s.len
+}
+
+// Copy creates a deep copy of the set with the same hasher.
+func (s *HashSet[K]) Copy() *HashSet[K] {
+	resultset := &HashSet[K]{buckets: make(map[uint64][]K, len(s.buckets)), len: s.len, hasher: s.hasher}
+	for h, bucket := range s.buckets {
+		resultset.buckets[h] = slices.Clone(bucket)
+	}
+	return resultset
+}
+
+// Elements returns all the keys in the set as an unordered slice.
+func (s *HashSet[K]) Elements() []K {
+	keys := make([]K, 0, s.len)
+	for k := range s.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// All returns an iterator over all the keys in the set in no particular order.
+// The set must not be modified during the iteration.
+func (s *HashSet[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, bucket := range s.buckets {
+			for _, k := range bucket {
+				if !yield(k) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Contains checks if the set contains all of the given keys.
+func (s *HashSet[K]) Contains(key K, keys ...K) bool {
+	// An empty set contains no keys.
+	if s.len == 0 {
+		return false
+	}
+
+	if !s.has(key) {
+		return false
+	}
+	for i := range keys {
+		if !s.has(keys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add inserts keys into the set.
+func (s *HashSet[K]) Add(key K, keys ...K) {
+	s.add(key)
+	for i := range keys {
+		s.add(keys[i])
+	}
+}
+
+// Del removes keys from the set.
+func (s *HashSet[K]) Del(key K, keys ...K) {
+	// An empty set contains no keys.
+	if s.len == 0 {
+		return
+	}
+
+	s.del(key)
+	for i := range keys {
+		s.del(keys[i])
+	}
+}
+
+// Equal checks if the sets contain the same keys.
+func (s *HashSet[K]) Equal(other *HashSet[K]) bool {
+	// The same set is always equal to itself.
+	if s == other {
+		return true
+	}
+	if s.len != other.len {
+		return false
+	}
+	for k := range other.All() {
+		if !s.has(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns the union of all the sets: ⋃(s, other, others) = s ∪ other ∪ others[0] ∪ others[1] ...
+func (s *HashSet[K]) Union(other *HashSet[K], others ...*HashSet[K]) *HashSet[K] {
+	// The union of a set with itself is the set.
+	if len(others) == 0 && s == other {
+		return s.Copy()
+	}
+
+	resultset := s.Copy()
+	resultset.Update(other, others...)
+	return resultset
+}
+
+// Update is like Union, but modifies the set in place.
+func (s *HashSet[K]) Update(a *HashSet[K], sets ...*HashSet[K]) {
+	// The union of a set with itself is the set.
+	if len(sets) == 0 && s == a {
+		return
+	}
+
+	for k := range a.All() {
+		s.add(k)
+	}
+	for i := range sets {
+		for k := range sets[i].All() {
+			s.add(k)
+		}
+	}
+}
+
+// Intersection returns the intersection of all the sets: ⋂(s, other, others) = s ∩ other ∩ others[0] ∩ others[1] ...
+func (s *HashSet[K]) Intersection(other *HashSet[K], others ...*HashSet[K]) *HashSet[K] {
+	resultset := s.Copy()
+	resultset.Intersect(other, others...)
+	return resultset
+}
+
+// Intersect is like Intersection, but modifies the set in place.
+func (s *HashSet[K]) Intersect(a *HashSet[K], sets ...*HashSet[K]) {
+	// The result will be empty if this set is empty.
+	if s.len == 0 {
+		return
+	}
+
+	// The intersection of a set with itself is the set.
+	if len(sets) == 0 && s == a {
+		return
+	}
+
+	sets = append(sets, a)
+	s.deleteFunc(func(k K) bool {
+		for i := range sets {
+			if !sets[i].has(k) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Difference returns the difference of all the sets: s ∖ other ∖ others[0] ∖ others[1] ...
+func (s *HashSet[K]) Difference(other *HashSet[K], others ...*HashSet[K]) *HashSet[K] {
+	resultset := s.Copy()
+	resultset.Remove(other, others...)
+	return resultset
+}
+
+// Remove is like Difference, but modifies the set in place.
+func (s *HashSet[K]) Remove(a *HashSet[K], sets ...*HashSet[K]) {
+	// The result will be empty if this set is empty.
+	if s.len == 0 {
+		return
+	}
+
+	// The result will be empty if we're removing the set from itself.
+	if s == a {
+		s.clear()
+		return
+	}
+
+	sets = append(sets, a)
+	s.deleteFunc(func(k K) bool {
+		for i := range sets {
+			if sets[i].has(k) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// SymmetricDifference returns the difference between the union and intersection
+// of all the sets: ⋃(s, other, others) ∖ ⋂(s, other, others).
+func (s *HashSet[K]) SymmetricDifference(other *HashSet[K], others ...*HashSet[K]) *HashSet[K] {
+	resultset := s.Copy()
+	resultset.SymmetricRemove(other, others...)
+	return resultset
+}
+
+// SymmetricRemove is like SymmetricDifference, but modifies the set in place.
+func (s *HashSet[K]) SymmetricRemove(a *HashSet[K], sets ...*HashSet[K]) {
+	// The symmetric difference of a set with itself is the empty set.
+	if len(sets) == 0 && s == a {
+		s.clear()
+		return
+	}
+
+	rm := s.Intersection(a, sets...)
+	s.Update(a, sets...)
+	s.Remove(rm)
+}
+
+// find returns the hash of key and its index in the bucket, or -1 if it's not in the set.
+func (s *HashSet[K]) find(key K) (uint64, int) {
+	h := s.hasher.Hash(key)
+	return h, slices.IndexFunc(s.buckets[h], func(k K) bool { return s.hasher.Equal(k, key) })
+}
+
+func (s *HashSet[K]) has(key K) bool {
+	// An empty set contains no keys, and it may have no hasher to look for them.
+	if s.len == 0 {
+		return false
+	}
+	_, i := s.find(key)
+	return i >= 0
+}
+
+func (s *HashSet[K]) add(key K) {
+	if s.hasher == nil {
+		panic("set: HashSet has no Hasher, create it with NewHashSet()")
+	}
+	if s.buckets == nil {
+		s.buckets = make(map[uint64][]K)
+	}
+	h, i := s.find(key)
+	if i < 0 {
+		s.buckets[h] = append(s.buckets[h], key)
+		s.len++
+	}
+}
+
+func (s *HashSet[K]) del(key K) {
+	if s.len == 0 {
+		return
+	}
+	h, i := s.find(key)
+	if i < 0 {
+		return
+	}
+	if len(s.buckets[h]) == 1 {
+		delete(s.buckets, h)
+	} else {
+		s.buckets[h] = slices.Delete(s.buckets[h], i, i+1)
+	}
+	s.len--
+}
+
+// deleteFunc removes the keys for which del returns true.
+func (s *HashSet[K]) deleteFunc(del func(K) bool) {
+	for h, bucket := range s.buckets {
+		bucket = slices.DeleteFunc(bucket, del)
+		s.len -= len(s.buckets[h]) - len(bucket)
+		if len(bucket) == 0 {
+			delete(s.buckets, h)
+		} else {
+			s.buckets[h] = bucket
+		}
+	}
+}
+
+func (s *HashSet[K]) clear() {
+	clear(s.buckets)
+	s.len = 0
+}
@@ -0,0 +1,179 @@
+package set
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collidingHasher hashes all the keys the same, so that they all end up in the same bucket.
+type collidingHasher struct{ KeyHasher[E] }
+
+func (collidingHasher) Hash(E) uint64 { return 42 }
+
+// checkHashSet checks the keys of a set of byte slices and that its length is consistent.
+func checkHashSet(t *testing.T, expected []string, s *HashSet[[]byte]) {
+	t.Helper()
+	actual := []string{}
+	for k := range s.All() {
+		actual = append(actual, string(k))
+	}
+	slices.Sort(actual)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, len(expected), s.Len())
+	assert.Len(t, s.Elements(), len(expected))
+}
+
+func bytesSet(keys ...string) *HashSet[[]byte] {
+	s := NewHashSet[[]byte](ByteSliceHasher{})
+	for _, k := range keys {
+		s.Add([]byte(k))
+	}
+	return s
+}
+
+func TestNewHashSet(t *testing.T) {
+	t.Parallel()
+
+	checkHashSet(t, []string{}, NewHashSet[[]byte](ByteSliceHasher{}))
+	checkHashSet(t, []string{"a", "b"}, NewHashSet(ByteSliceHasher{}, []byte("b"), []byte("a"), []byte("b")))
+	s := bytesSet("a", "b")
+	c := s.Copy()
+	c.Add([]byte("c"))
+	checkHashSet(t, []string{"a", "b"}, s)
+	checkHashSet(t, []string{"a", "b", "c"}, c)
+}
+
+func TestHashSet_Zero(t *testing.T) {
+	t.Parallel()
+
+	var zero HashSet[[]byte]
+	checkHashSet(t, []string{}, &zero)
+	assert.False(t, zero.Contains([]byte("a")))
+	zero.Del([]byte("a"))
+	assert.True(t, zero.Equal(zero.Copy()))
+	assert.False(t, zero.Equal(bytesSet("a")))
+
+	s := bytesSet("a", "b")
+	s.Intersect(&zero)
+	checkHashSet(t, []string{}, s)
+	s = bytesSet("a", "b")
+	s.Remove(&zero)
+	checkHashSet(t, []string{"a", "b"}, s)
+	checkHashSet(t, []string{"a", "b"}, s.Union(&zero))
+	zero.Update(NewHashSet[[]byte](ByteSliceHasher{}))
+	checkHashSet(t, []string{}, &zero)
+
+	assert.PanicsWithValue(t, "set: HashSet has no Hasher, create it with NewHashSet()", func() { zero.Add([]byte("a")) })
+	assert.Panics(t, func() { zero.Update(s) })
+}
+
+func TestHashSet_AddDel(t *testing.T) {
+	t.Parallel()
+
+	s := NewHashSet[string](FoldHasher{})
+	assert.False(t, s.Contains("a"))
+	s.Del("a")
+	s.Add("Go", "GO", "go", "Rust")
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Contains("gO", "rust"))
+	assert.False(t, s.Contains("go", "zig"))
+	s.Del("RUST", "zig")
+	assert.Equal(t, []string{"Go"}, s.Elements())
+	s.Del("go")
+	assert.Zero(t, s.Len())
+	assert.Empty(t, s.Elements())
+}
+
+func TestHashSet_Collisions(t *testing.T) {
+	t.Parallel()
+
+	s := NewHashSet[E](collidingHasher{})
+	model := New[E]()
+	for range 1000 {
+		k := rand.IntN(50)
+		if rand.IntN(3) == 0 {
+			s.Del(k)
+			model.Del(k)
+		} else {
+			s.Add(k)
+			model.Add(k)
+		}
+		assert.Equal(t, len(model), s.Len())
+	}
+	assert.Equal(t, model, Collect(s.All()))
+	assert.LessOrEqual(t, len(s.buckets), 1)
+}
+
+func TestHashSet_Equal(t *testing.T) {
+	t.Parallel()
+
+	s := bytesSet("a", "b")
+	assert.True(t, s.Equal(s))
+	assert.True(t, s.Equal(bytesSet("b", "a")))
+	assert.False(t, s.Equal(bytesSet("a")))
+	assert.False(t, s.Equal(bytesSet("a", "c")))
+	assert.True(t, bytesSet().Equal(bytesSet()))
+}
+
+func TestHashSet_Algebra(t *testing.T) {
+	t.Parallel()
+
+	h1, h2, h3 := bytesSet("0", "1", "2", "3", "4"), bytesSet("3", "4", "5", "6", "7"), bytesSet("6", "7", "8")
+	empty := bytesSet()
+	tests := []struct {
+		name     string
+		op       func(s, a *HashSet[[]byte], sets ...*HashSet[[]byte]) *HashSet[[]byte]
+		inplace  func(s, a *HashSet[[]byte], sets ...*HashSet[[]byte])
+		s, a     *HashSet[[]byte]
+		sets     []*HashSet[[]byte]
+		expected []string
+	}{
+		{"h1∪h1", (*HashSet[[]byte]).Union, (*HashSet[[]byte]).Update, h1, h1, nil, []string{"0", "1", "2", "3", "4"}},
+		{"h1∪h2", (*HashSet[[]byte]).Union, (*HashSet[[]byte]).Update, h1, h2, nil, []string{"0", "1", "2", "3", "4", "5", "6", "7"}},
+		{"h1∪∅∪h3", (*HashSet[[]byte]).Union, (*HashSet[[]byte]).Update, h1, empty, []*HashSet[[]byte]{h3}, []string{"0", "1", "2", "3", "4", "6", "7", "8"}},
+		{"h1∩h1", (*HashSet[[]byte]).Intersection, (*HashSet[[]byte]).Intersect, h1, h1, nil, []string{"0", "1", "2", "3", "4"}},
+		{"h1∩h2", (*HashSet[[]byte]).Intersection, (*HashSet[[]byte]).Intersect, h1, h2, nil, []string{"3", "4"}},
+		{"h2∩h1∩h3", (*HashSet[[]byte]).Intersection, (*HashSet[[]byte]).Intersect, h2, h1, []*HashSet[[]byte]{h3}, []string{}},
+		{"h1∩∅", (*HashSet[[]byte]).Intersection, (*HashSet[[]byte]).Intersect, h1, empty, nil, []string{}},
+		{"h1∖h1", (*HashSet[[]byte]).Difference, (*HashSet[[]byte]).Remove, h1, h1, nil, []string{}},
+		{"h2∖h1", (*HashSet[[]byte]).Difference, (*HashSet[[]byte]).Remove, h2, h1, nil, []string{"5", "6", "7"}},
+		{"h2∖h1∖h3", (*HashSet[[]byte]).Difference, (*HashSet[[]byte]).Remove, h2, h1, []*HashSet[[]byte]{h3}, []string{"5"}},
+		{"h1∖∅", (*HashSet[[]byte]).Difference, (*HashSet[[]byte]).Remove, h1, empty, nil, []string{"0", "1", "2", "3", "4"}},
+		{"h1△h1", (*HashSet[[]byte]).SymmetricDifference, (*HashSet[[]byte]).SymmetricRemove, h1, h1, nil, []string{}},
+		{"h1△h2", (*HashSet[[]byte]).SymmetricDifference, (*HashSet[[]byte]).SymmetricRemove, h1, h2, nil, []string{"0", "1", "2", "5", "6", "7"}},
+		{"h1△h2△h3", (*HashSet[[]byte]).SymmetricDifference, (*HashSet[[]byte]).SymmetricRemove, h1, h2, []*HashSet[[]byte]{h3}, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkHashSet(t, tt.expected, tt.op(tt.s, tt.a, tt.sets...))
+
+			s := tt.s.Copy()
+			a := tt.a
+			if tt.s == tt.a {
+				a = s
+			}
+			tt.inplace(s, a, tt.sets...)
+			checkHashSet(t, tt.expected, s)
+		})
+	}
+
+	// The operands are never modified.
+	checkHashSet(t, []string{"0", "1", "2", "3", "4"}, h1)
+	checkHashSet(t, []string{"3", "4", "5", "6", "7"}, h2)
+	checkHashSet(t, []string{"6", "7", "8"}, h3)
+}
+
+func TestHashSet_Fold(t *testing.T) {
+	t.Parallel()
+
+	tags := NewHashSet[string](FoldHasher{}, strings.Fields("Go go GOLANG rust Zig")...)
+	assert.Equal(t, 4, tags.Len())
+	other := NewHashSet[string](FoldHasher{}, "RUST", "zig", "c")
+	assert.Equal(t, 2, tags.Intersection(other).Len())
+	assert.Equal(t, 5, tags.Union(other).Len())
+	assert.Equal(t, []string{"c"}, other.Difference(tags).Elements())
+}
@@ -0,0 +1,275 @@
+package set
+
+import (
+	"iter"
+)
+
+// Linked is a set that remembers the order in which keys were inserted. Iteration always
+// visits the keys from the oldest to the newest one. The zero value is an empty set ready
+// to use. Linked sets must not be copied by value, use Copy() instead.
+type Linked[K comparable] struct {
+	index map[K]*linkedEntry[K]
+	// root is a sentinel: root.next is the front and root.prev the back of the list.
+	root linkedEntry[K]
+}
+
+type linkedEntry[K comparable] struct {
+	key        K
+	prev, next *linkedEntry[K]
+}
+
+// NewLinked creates a new linked set that contains all the given keys in the given order.
+func NewLinked[K comparable](keys ...K) *Linked[K] {
+	s := &Linked[K]{}
+	s.init(len(keys))
+	for i := range keys {
+		s.add(keys[i])
+	}
+	return s
+}
+
+// Len returns the number of keys in the set.
+func (s *Linked[K]) Len() int {
+	return len(s.index)
+}
+
+// Copy creates a deep copy of the set with the same order.
+func (s *Linked[K]) Copy() *Linked[K] {
+	resultset := &Linked[K]{}
+	resultset.init(s.Len())
+	for k := range s.All() {
+		resultset.add(k)
+	}
+	return resultset
+}
+
+// Elements returns all the keys in the set in insertion order.
+func (s *Linked[K]) Elements() []K {
+	keys := make([]K, 0, s.Len())
+	for k := range s.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// All returns an iterator over all the keys in the set in insertion order.
+// The set must not be modified during the iteration.
+func (s *Linked[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		if s.index == nil {
+			return
+		}
+		for e := s.root.next; e != &s.root; e = e.next {
+			if !yield(e.key) {
+				return
+			}
+		}
+	}
+}
+
+// Contains checks if the set contains all of the given keys.
+func (s *Linked[K]) Contains(key K, keys ...K) bool {
+	if !s.has(key) {
+		return false
+	}
+	for i := range keys {
+		if !s.has(keys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add appends keys to the back of the set. Keys that are already in the set keep their position.
+func (s *Linked[K]) Add(key K, keys ...K) {
+	s.init(0)
+	s.add(key)
+	for i := range keys {
+		s.add(keys[i])
+	}
+}
+
+// Del removes keys from the set.
+func (s *Linked[K]) Del(key K, keys ...K) {
+	// An empty set contains no keys.
+	if len(s.index) == 0 {
+		return
+	}
+
+	s.del(key)
+	for i := range keys {
+		s.del(keys[i])
+	}
+}
+
+// MoveToBack moves key to the back of the set as if it was just inserted.
+// It reports whether the key is in the set.
+func (s *Linked[K]) MoveToBack(key K) bool {
+	e, ok := s.index[key]
+	if !ok {
+		return false
+	}
+	s.unlink(e)
+	s.pushBack(e)
+	return true
+}
+
+// Front returns the oldest key in the set, if there is one.
+func (s *Linked[K]) Front() (key K, ok bool) {
+	if len(s.index) == 0 {
+		return
+	}
+	return s.root.next.key, true
+}
+
+// PopFront removes and returns the oldest key in the set, if there is one.
+func (s *Linked[K]) PopFront() (key K, ok bool) {
+	if len(s.index) == 0 {
+		return
+	}
+	e := s.root.next
+	s.unlink(e)
+	delete(s.index, e.key)
+	return e.key, true
+}
+
+// Update adds the keys of all the other sets that are not yet in the set, in their order: s ∪ a ∪ sets[0] ...
+func (s *Linked[K]) Update(a *Linked[K], sets ...*Linked[K]) {
+	// The union of a set with itself is the set.
+	if len(sets) == 0 && s == a {
+		return
+	}
+
+	s.init(0)
+	for k := range a.All() {
+		s.add(k)
+	}
+	for i := range sets {
+		for k := range sets[i].All() {
+			s.add(k)
+		}
+	}
+}
+
+// Intersect keeps only the keys that are in all the other sets: s ∩ a ∩ sets[0] ...
+func (s *Linked[K]) Intersect(a *Linked[K], sets ...*Linked[K]) {
+	// The result will be empty if this set is empty.
+	if len(s.index) == 0 {
+		return
+	}
+
+	// The intersection of a set with itself is the set.
+	if len(sets) == 0 && s == a {
+		return
+	}
+
+	sets = append(sets, a)
+	s.deleteFunc(func(k K) bool {
+		for i := range sets {
+			if !sets[i].has(k) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Remove deletes the keys that are in any of the other sets: s ∖ a ∖ sets[0] ...
+func (s *Linked[K]) Remove(a *Linked[K], sets ...*Linked[K]) {
+	// The result will be empty if this set is empty.
+	if len(s.index) == 0 {
+		return
+	}
+
+	// The result will be empty if we're removing the set from itself.
+	if s == a {
+		s.clear()
+		return
+	}
+
+	sets = append(sets, a)
+	s.deleteFunc(func(k K) bool {
+		for i := range sets {
+			if sets[i].has(k) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// SymmetricRemove keeps the keys that are in some but not all of the sets: ⋃(s, a, sets) ∖ ⋂(s, a, sets).
+// Keys of s stay in their order, followed by the new keys of the other sets.
+func (s *Linked[K]) SymmetricRemove(a *Linked[K], sets ...*Linked[K]) {
+	// The symmetric difference of a set with itself is the empty set.
+	if len(sets) == 0 && s == a {
+		s.clear()
+		return
+	}
+
+	rm := s.Copy()
+	rm.Intersect(a, sets...)
+	s.Update(a, sets...)
+	s.Remove(rm)
+}
+
+func (s *Linked[K]) init(size int) {
+	if s.index == nil {
+		s.index = make(map[K]*linkedEntry[K], size)
+		s.root.next = &s.root
+		s.root.prev = &s.root
+	}
+}
+
+func (s *Linked[K]) has(key K) bool {
+	_, exists := s.index[key]
+	return exists
+}
+
+func (s *Linked[K]) add(key K) {
+	if _, exists := s.index[key]; exists {
+		return
+	}
+	e := &linkedEntry[K]{key: key}
+	s.index[key] = e
+	s.pushBack(e)
+}
+
+func (s *Linked[K]) del(key K) {
+	if e, exists := s.index[key]; exists {
+		s.unlink(e)
+		delete(s.index, key)
+	}
+}
+
+// deleteFunc removes all the keys for which del returns true.
+func (s *Linked[K]) deleteFunc(del func(K) bool) {
+	for e := s.root.next; e != &s.root; {
+		next := e.next
+		if del(e.key) {
+			s.unlink(e)
+			delete(s.index, e.key)
+		}
+		e = next
+	}
+}
+
+func (s *Linked[K]) clear() {
+	clear(s.index)
+	s.root.next = &s.root
+	s.root.prev = &s.root
+}
+
+func (s *Linked[K]) pushBack(e *linkedEntry[K]) {
+	e.prev = s.root.prev
+	e.next = &s.root
+	e.prev.next = e
+	s.root.prev = e
+}
+
+func (s *Linked[K]) unlink(e *linkedEntry[K]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+}
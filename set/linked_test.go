@@ -0,0 +1,148 @@
+package set
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var l1 = NewLinked[E](4, 3, 2, 1, 0)
+var l2 = NewLinked[E](3, 4, 5, 6, 7)
+var l3 = NewLinked[E](10, 9, 8, 7, 6)
+
+func TestNewLinked(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected []E, s *Linked[E]) {
+		assert.Equal(t, expected, s.Elements())
+		assert.Equal(t, len(expected), s.Len())
+	}
+
+	var zero Linked[E]
+	t.Run("zero", func(t *testing.T) { check(t, []E{}, &zero) })
+	t.Run("none", func(t *testing.T) { check(t, []E{}, NewLinked[E]()) })
+	t.Run("{2,1,2,0}", func(t *testing.T) { check(t, []E{2, 1, 0}, NewLinked[E](2, 1, 2, 0)) })
+	t.Run("copy", func(t *testing.T) { check(t, []E{4, 3, 2, 1, 0}, l1.Copy()) })
+}
+
+func TestLinked_AddDel(t *testing.T) {
+	t.Parallel()
+
+	var s Linked[E]
+	s.Del(1)
+	s.Add(3, 1, 2, 1)
+	assert.Equal(t, []E{3, 1, 2}, s.Elements())
+	assert.True(t, s.Contains(1, 2, 3))
+	assert.False(t, s.Contains(1, 4))
+	s.Add(3)
+	assert.Equal(t, []E{3, 1, 2}, s.Elements())
+
+	s.Del(1, 5)
+	assert.Equal(t, []E{3, 2}, s.Elements())
+	s.Add(1)
+	assert.Equal(t, []E{3, 2, 1}, s.Elements())
+	s.Del(3, 2, 1)
+	assert.Zero(t, s.Len())
+	assert.Empty(t, slices.Collect(s.All()))
+}
+
+func TestLinked_MoveToBack(t *testing.T) {
+	t.Parallel()
+
+	s := NewLinked[E](1, 2, 3)
+	assert.True(t, s.MoveToBack(1))
+	assert.Equal(t, []E{2, 3, 1}, s.Elements())
+	assert.True(t, s.MoveToBack(1))
+	assert.Equal(t, []E{2, 3, 1}, s.Elements())
+	assert.False(t, s.MoveToBack(4))
+	assert.Equal(t, []E{2, 3, 1}, s.Elements())
+}
+
+func TestLinked_PopFront(t *testing.T) {
+	t.Parallel()
+
+	s := NewLinked[E](2, 1)
+	k, ok := s.Front()
+	assert.True(t, ok)
+	assert.Equal(t, 2, k)
+	k, ok = s.PopFront()
+	assert.True(t, ok)
+	assert.Equal(t, 2, k)
+	k, ok = s.PopFront()
+	assert.True(t, ok)
+	assert.Equal(t, 1, k)
+	_, ok = s.PopFront()
+	assert.False(t, ok)
+	_, ok = s.Front()
+	assert.False(t, ok)
+	assert.False(t, s.Contains(1))
+
+	var zero Linked[E]
+	_, ok = zero.PopFront()
+	assert.False(t, ok)
+}
+
+func TestLinked_All(t *testing.T) {
+	t.Parallel()
+
+	for k := range l1.All() {
+		assert.Equal(t, 4, k)
+		break
+	}
+	assert.Equal(t, []E{10, 9, 8, 7, 6}, slices.Collect(l3.All()))
+}
+
+func TestLinked_Algebra(t *testing.T) {
+	t.Parallel()
+
+	null := &Linked[E]{}
+	check := func(t *testing.T, expected []E, s *Linked[E], op func(s *Linked[E])) {
+		s = s.Copy()
+		op(s)
+		assert.Equal(t, expected, s.Elements())
+		assert.Equal(t, len(expected), s.Len())
+	}
+	t.Run("update", func(t *testing.T) {
+		update := func(a *Linked[E], sets ...*Linked[E]) func(*Linked[E]) {
+			return func(s *Linked[E]) { s.Update(a, sets...) }
+		}
+		check(t, []E{4, 3, 2, 1, 0, 5, 6, 7}, l1, update(l2))
+		check(t, []E{3, 4, 5, 6, 7, 2, 1, 0}, l2, update(l1))
+		check(t, []E{4, 3, 2, 1, 0, 10, 9, 8, 7, 6, 5}, l1, update(l3, l2))
+		check(t, []E{4, 3, 2, 1, 0}, l1, update(null))
+		check(t, []E{4, 3, 2, 1, 0}, null, update(l1))
+		check(t, []E{4, 3, 2, 1, 0}, l1, func(s *Linked[E]) { s.Update(s) })
+	})
+	t.Run("intersect", func(t *testing.T) {
+		intersect := func(a *Linked[E], sets ...*Linked[E]) func(*Linked[E]) {
+			return func(s *Linked[E]) { s.Intersect(a, sets...) }
+		}
+		check(t, []E{4, 3}, l1, intersect(l2))
+		check(t, []E{3, 4}, l2, intersect(l1))
+		check(t, []E{}, l1, intersect(l2, l3))
+		check(t, []E{}, l1, intersect(null))
+		check(t, []E{}, null, intersect(l1))
+		check(t, []E{4, 3, 2, 1, 0}, l1, func(s *Linked[E]) { s.Intersect(s) })
+	})
+	t.Run("remove", func(t *testing.T) {
+		remove := func(a *Linked[E], sets ...*Linked[E]) func(*Linked[E]) {
+			return func(s *Linked[E]) { s.Remove(a, sets...) }
+		}
+		check(t, []E{2, 1, 0}, l1, remove(l2))
+		check(t, []E{5}, l2, remove(l1, l3))
+		check(t, []E{4, 3, 2, 1, 0}, l1, remove(null))
+		check(t, []E{}, null, remove(l1))
+		check(t, []E{}, l1, func(s *Linked[E]) { s.Remove(s) })
+	})
+	t.Run("symmetric remove", func(t *testing.T) {
+		symmetricRemove := func(a *Linked[E], sets ...*Linked[E]) func(*Linked[E]) {
+			return func(s *Linked[E]) { s.SymmetricRemove(a, sets...) }
+		}
+		check(t, []E{2, 1, 0, 5, 6, 7}, l1, symmetricRemove(l2))
+		check(t, []E{5, 6, 7, 2, 1, 0}, l2, symmetricRemove(l1))
+		check(t, []E{4, 3, 2, 1, 0, 5, 6, 7, 10, 9, 8}, l1, symmetricRemove(l2, l3))
+		check(t, []E{4, 3, 2, 1, 0}, null, symmetricRemove(l1))
+		check(t, []E{}, l1, func(s *Linked[E]) { s.SymmetricRemove(s) })
+	})
+}
@@ -0,0 +1,219 @@
+package set
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Multiset is a set that counts how many times every key is in it, also known as a bag.
+// Keys with a count of zero are not in the multiset. The zero value is an empty multiset ready
+// to use. Multisets must not be copied by value, use Copy() instead.
+type Multiset[K comparable] struct {
+	counts map[K]int
+	// len is the sum of all the counts.
+	len int
+}
+
+// NewMultiset creates a new multiset that contains all the given keys, as many times as they
+// are given.
+func NewMultiset[K comparable](keys ...K) *Multiset[K] {
+	s := &Multiset[K]{counts: make(map[K]int, len(keys))}
+	for i := range keys {
+		s.set(keys[i], s.counts[keys[i]]+1)
+	}
+	return s
+}
+
+// Len returns the number of keys in the multiset, counting every key as many times as it is there.
+func (s *Multiset[K]) Len() int {
+	return s.len
+}
+
+// Distinct returns the number of different keys in the multiset.
+func (s *Multiset[K]) Distinct() int {
+	return len(s.counts)
+}
+
+// Copy creates a deep copy of the multiset.
+func (s *Multiset[K]) Copy() *Multiset[K] {
+	return &Multiset[K]{counts: maps.Clone(s.counts), len: s.len}
+}
+
+// ToSet creates a new Set that contains all the different keys of the multiset.
+func (s *Multiset[K]) ToSet() Set[K] {
+	resultset := make(Set[K], len(s.counts))
+	for k := range s.counts {
+		resultset[k] = struct{}{}
+	}
+	return resultset
+}
+
+// All returns an iterator over all the different keys in the multiset and their counts,
+// in no particular order.
+func (s *Multiset[K]) All() iter.Seq2[K, int] {
+	return func(yield func(K, int) bool) {
+		for k, n := range s.counts {
+			if !yield(k, n) {
+				return
+			}
+		}
+	}
+}
+
+// Count returns how many times key is in the multiset.
+func (s *Multiset[K]) Count(key K) int {
+	return s.counts[key]
+}
+
+// Contains checks if the multiset contains all of the given keys at least once.
+func (s *Multiset[K]) Contains(key K, keys ...K) bool {
+	if s.counts[key] == 0 {
+		return false
+	}
+	for i := range keys {
+		if s.counts[keys[i]] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Add inserts key into the multiset n times. It panics if n is negative.
+func (s *Multiset[K]) Add(key K, n int) {
+	if n < 0 {
+		panic("set: Add() called with negative n")
+	}
+	s.set(key, s.counts[key]+n)
+}
+
+// Remove removes key from the multiset n times, or as many times as it is there, and returns
+// how many times it was removed. It panics if n is negative.
+func (s *Multiset[K]) Remove(key K, n int) int {
+	if n < 0 {
+		panic("set: Remove() called with negative n")
+	}
+	n = min(n, s.counts[key])
+	s.set(key, s.counts[key]-n)
+	return n
+}
+
+// MostCommon returns the n keys with the highest counts, from the highest to the lowest count.
+// Keys with equal counts are in no particular order. All the keys are returned if n is negative
+// or larger than Distinct().
+func (s *Multiset[K]) MostCommon(n int) []K {
+	keys := slices.Collect(maps.Keys(s.counts))
+	slices.SortFunc(keys, func(a, b K) int {
+		return cmp.Compare(s.counts[b], s.counts[a])
+	})
+	if n >= 0 && n < len(keys) {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+// Equal checks if the multisets contain the same keys the same number of times.
+func (s *Multiset[K]) Equal(other *Multiset[K]) bool {
+	// The same multiset is always equal to itself.
+	if s == other {
+		return true
+	}
+	return s.len == other.len && maps.Equal(s.counts, other.counts)
+}
+
+// Union returns the union of all the multisets, which contains every key as many times as the
+// multiset that contains it the most times.
+func (s *Multiset[K]) Union(other *Multiset[K], others ...*Multiset[K]) *Multiset[K] {
+	// The union of a multiset with itself is the multiset.
+	if len(others) == 0 && s == other {
+		return s.Copy()
+	}
+
+	resultset := s.Copy()
+	for _, o := range append([]*Multiset[K]{other}, others...) {
+		for k, n := range o.counts {
+			if n > resultset.counts[k] {
+				resultset.set(k, n)
+			}
+		}
+	}
+	return resultset
+}
+
+// Sum returns the sum of all the multisets, which contains every key as many times as all the
+// multisets together.
+func (s *Multiset[K]) Sum(other *Multiset[K], others ...*Multiset[K]) *Multiset[K] {
+	resultset := s.Copy()
+	for _, o := range append([]*Multiset[K]{other}, others...) {
+		for k, n := range o.counts {
+			resultset.set(k, resultset.counts[k]+n)
+		}
+	}
+	return resultset
+}
+
+// Intersection returns the intersection of all the multisets, which contains every key as many
+// times as the multiset that contains it the least times.
+func (s *Multiset[K]) Intersection(other *Multiset[K], others ...*Multiset[K]) *Multiset[K] {
+	// The intersection of a multiset with itself is the multiset.
+	if len(others) == 0 && s == other {
+		return s.Copy()
+	}
+
+	others = append(others, other)
+	resultset := &Multiset[K]{}
+	// The result will be empty if any of the multisets are empty.
+	for i := range others {
+		if others[i].len == 0 {
+			return resultset
+		}
+	}
+	for k, n := range s.counts {
+		for i := range others {
+			if n = min(n, others[i].counts[k]); n == 0 {
+				break
+			}
+		}
+		resultset.set(k, n)
+	}
+	return resultset
+}
+
+// Difference returns the difference of all the multisets, which contains every key as many
+// times as s contains it more than all the others together.
+func (s *Multiset[K]) Difference(other *Multiset[K], others ...*Multiset[K]) *Multiset[K] {
+	// The difference of a multiset with itself is the empty multiset.
+	if s == other {
+		return &Multiset[K]{}
+	}
+
+	others = append(others, other)
+	resultset := &Multiset[K]{}
+	for k, n := range s.counts {
+		for i := range others {
+			if n -= others[i].counts[k]; n <= 0 {
+				break
+			}
+		}
+		resultset.set(k, max(n, 0))
+	}
+	return resultset
+}
+
+// set changes the count of key to n, removing the key if n is zero.
+func (s *Multiset[K]) set(key K, n int) {
+	old := s.counts[key]
+	if n == old {
+		return
+	}
+	s.len += n - old
+	if n == 0 {
+		delete(s.counts, key)
+		return
+	}
+	if s.counts == nil {
+		s.counts = make(map[K]int)
+	}
+	s.counts[key] = n
+}
@@ -0,0 +1,119 @@
+package set
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var m1 = NewMultiset[E](1, 1, 1, 2, 2, 3)
+var m2 = NewMultiset[E](1, 2, 2, 2, 4)
+var m3 = NewMultiset[E](1, 1, 2, 5)
+
+// checkMultiset checks the counts of s and that its length is consistent.
+func checkMultiset(t *testing.T, expected map[E]int, s *Multiset[E]) {
+	t.Helper()
+	total := 0
+	for _, n := range expected {
+		total += n
+	}
+	assert.Equal(t, expected, maps.Collect(s.All()))
+	assert.Equal(t, total, s.Len())
+	assert.Equal(t, len(expected), s.Distinct())
+}
+
+func TestNewMultiset(t *testing.T) {
+	t.Parallel()
+
+	var zero Multiset[E]
+	t.Run("zero", func(t *testing.T) { checkMultiset(t, map[E]int{}, &zero) })
+	t.Run("none", func(t *testing.T) { checkMultiset(t, map[E]int{}, NewMultiset[E]()) })
+	t.Run("m1", func(t *testing.T) { checkMultiset(t, map[E]int{1: 3, 2: 2, 3: 1}, m1) })
+	t.Run("copy", func(t *testing.T) { checkMultiset(t, map[E]int{1: 3, 2: 2, 3: 1}, m1.Copy()) })
+	t.Run("toset", func(t *testing.T) { assert.Equal(t, New[E](1, 2, 3), m1.ToSet()) })
+}
+
+func TestMultiset_AddRemove(t *testing.T) {
+	t.Parallel()
+
+	var s Multiset[E]
+	assert.Equal(t, 0, s.Remove(1, 1))
+	s.Add(1, 3)
+	s.Add(2, 1)
+	s.Add(3, 0)
+	checkMultiset(t, map[E]int{1: 3, 2: 1}, &s)
+	assert.Equal(t, 3, s.Count(1))
+	assert.Equal(t, 0, s.Count(3))
+	assert.True(t, s.Contains(1, 2))
+	assert.False(t, s.Contains(1, 3))
+
+	assert.Equal(t, 2, s.Remove(1, 2))
+	checkMultiset(t, map[E]int{1: 1, 2: 1}, &s)
+	assert.Equal(t, 1, s.Remove(2, 5))
+	checkMultiset(t, map[E]int{1: 1}, &s)
+	assert.False(t, s.Contains(2))
+	assert.Equal(t, 0, s.Remove(1, 0))
+	assert.Equal(t, 0, s.Remove(3, 1))
+	checkMultiset(t, map[E]int{1: 1}, &s)
+
+	assert.PanicsWithValue(t, "set: Add() called with negative n", func() { s.Add(1, -1) })
+	assert.PanicsWithValue(t, "set: Remove() called with negative n", func() { s.Remove(1, -1) })
+}
+
+func TestMultiset_MostCommon(t *testing.T) {
+	t.Parallel()
+
+	s := NewMultiset[E](1, 2, 2, 2, 3, 3)
+	assert.Equal(t, []E{2, 3, 1}, s.MostCommon(-1))
+	assert.Equal(t, []E{2, 3, 1}, s.MostCommon(5))
+	assert.Equal(t, []E{2, 3}, s.MostCommon(2))
+	assert.Empty(t, s.MostCommon(0))
+	assert.Empty(t, NewMultiset[E]().MostCommon(3))
+}
+
+func TestMultiset_Equal(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, m1.Equal(m1))
+	assert.True(t, m1.Equal(NewMultiset[E](3, 2, 1, 2, 1, 1)))
+	assert.False(t, m1.Equal(NewMultiset[E](3, 2, 1, 2, 1)))
+	assert.False(t, m1.Equal(m2))
+	assert.True(t, NewMultiset[E]().Equal(&Multiset[E]{}))
+}
+
+func TestMultiset_Algebra(t *testing.T) {
+	t.Parallel()
+
+	var empty Multiset[E]
+	tests := []struct {
+		name     string
+		actual   *Multiset[E]
+		expected map[E]int
+	}{
+		{"m1∪m1", m1.Union(m1), map[E]int{1: 3, 2: 2, 3: 1}},
+		{"m1∪m2", m1.Union(m2), map[E]int{1: 3, 2: 3, 3: 1, 4: 1}},
+		{"m1∪m2∪m3", m1.Union(m2, m3), map[E]int{1: 3, 2: 3, 3: 1, 4: 1, 5: 1}},
+		{"m1∪∅", m1.Union(&empty), map[E]int{1: 3, 2: 2, 3: 1}},
+		{"m1+m1", m1.Sum(m1), map[E]int{1: 6, 2: 4, 3: 2}},
+		{"m1+m2+m3", m1.Sum(m2, m3), map[E]int{1: 6, 2: 6, 3: 1, 4: 1, 5: 1}},
+		{"∅+∅", empty.Sum(&empty), map[E]int{}},
+		{"m1∩m1", m1.Intersection(m1), map[E]int{1: 3, 2: 2, 3: 1}},
+		{"m1∩m2", m1.Intersection(m2), map[E]int{1: 1, 2: 2}},
+		{"m1∩m2∩m3", m1.Intersection(m2, m3), map[E]int{1: 1, 2: 1}},
+		{"m1∩∅", m1.Intersection(&empty), map[E]int{}},
+		{"m1∖m1", m1.Difference(m1), map[E]int{}},
+		{"m1∖m2", m1.Difference(m2), map[E]int{1: 2, 3: 1}},
+		{"m2∖m1", m2.Difference(m1), map[E]int{2: 1, 4: 1}},
+		{"m1∖m2∖m3", m1.Difference(m2, m3), map[E]int{3: 1}},
+		{"m1∖∅", m1.Difference(&empty), map[E]int{1: 3, 2: 2, 3: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) { checkMultiset(t, tt.expected, tt.actual) })
+	}
+
+	// The operands are never modified.
+	checkMultiset(t, map[E]int{1: 3, 2: 2, 3: 1}, m1)
+	checkMultiset(t, map[E]int{1: 1, 2: 3, 4: 1}, m2)
+	checkMultiset(t, map[E]int{1: 2, 2: 1, 5: 1}, m3)
+}
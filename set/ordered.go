@@ -0,0 +1,460 @@
+package set
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+)
+
+// maxLevel limits the height of the skip list, which is plenty for 4^32 keys.
+const maxLevel = 32
+
+// Ordered is a set of ordered keys that keeps them sorted. It is backed by an indexable
+// skip list, so most operations take O(log n) time, including finding a key by its rank.
+// The zero value is an empty set ready to use. Ordered sets must not be copied by value,
+// use Copy() instead.
+type Ordered[K cmp.Ordered] struct {
+	head  *node[K]
+	level int
+	len   int
+	rng   uint64
+}
+
+type node[K cmp.Ordered] struct {
+	key  K
+	next []link[K]
+}
+
+// link points to the next node on one level of the skip list. The span is the number of
+// keys it skips over, so that the ranks of keys can be counted while searching. Links to the
+// end of the list span over the remaining keys.
+type link[K cmp.Ordered] struct {
+	node *node[K]
+	span int
+}
+
+// NewOrdered creates a new ordered set that contains all the given keys.
+func NewOrdered[K cmp.Ordered](keys ...K) *Ordered[K] {
+	sorted := slices.Clone(keys)
+	slices.Sort(sorted)
+	b := newOrderedBuilder[K]()
+	for _, k := range slices.Compact(sorted) {
+		b.add(k)
+	}
+	return b.done()
+}
+
+// Len returns the number of keys in the set.
+func (s *Ordered[K]) Len() int {
+	return s.len
+}
+
+// Copy creates a deep copy of the set.
+func (s *Ordered[K]) Copy() *Ordered[K] {
+	b := newOrderedBuilder[K]()
+	for x := s.first(); x != nil; x = x.next[0].node {
+		b.add(x.key)
+	}
+	return b.done()
+}
+
+// Elements returns all the keys in the set as a sorted slice.
+func (s *Ordered[K]) Elements() []K {
+	keys := make([]K, 0, s.len)
+	for x := s.first(); x != nil; x = x.next[0].node {
+		keys = append(keys, x.key)
+	}
+	return keys
+}
+
+// All returns an iterator over all the keys in the set in increasing order.
+// The set must not be modified during the iteration.
+func (s *Ordered[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for x := s.first(); x != nil; x = x.next[0].node {
+			if !yield(x.key) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys k with lo <= k < hi in increasing order.
+// The set must not be modified during the iteration.
+func (s *Ordered[K]) Range(lo, hi K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for x := s.ceiling(lo); x != nil && cmp.Less(x.key, hi); x = x.next[0].node {
+			if !yield(x.key) {
+				return
+			}
+		}
+	}
+}
+
+// Contains checks if the set contains all of the given keys.
+func (s *Ordered[K]) Contains(key K, keys ...K) bool {
+	if !s.has(key) {
+		return false
+	}
+	for i := range keys {
+		if !s.has(keys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add inserts keys into the set.
+func (s *Ordered[K]) Add(key K, keys ...K) {
+	s.add(key)
+	for i := range keys {
+		s.add(keys[i])
+	}
+}
+
+// Del removes keys from the set.
+func (s *Ordered[K]) Del(key K, keys ...K) {
+	s.del(key)
+	for i := range keys {
+		s.del(keys[i])
+	}
+}
+
+// Min returns the smallest key in the set, if there is one.
+func (s *Ordered[K]) Min() (key K, ok bool) {
+	if x := s.first(); x != nil {
+		return x.key, true
+	}
+	return
+}
+
+// Max returns the largest key in the set, if there is one.
+func (s *Ordered[K]) Max() (key K, ok bool) {
+	if s.len == 0 {
+		return
+	}
+	x := s.head
+	for l := s.level - 1; l >= 0; l-- {
+		for x.next[l].node != nil {
+			x = x.next[l].node
+		}
+	}
+	return x.key, true
+}
+
+// Floor returns the largest key in the set that is smaller than or equal to key, if there is one.
+func (s *Ordered[K]) Floor(key K) (floor K, ok bool) {
+	if s.len == 0 {
+		return
+	}
+	x := s.head
+	for l := s.level - 1; l >= 0; l-- {
+		for x.next[l].node != nil && cmp.Compare(x.next[l].node.key, key) <= 0 {
+			x = x.next[l].node
+		}
+	}
+	if x == s.head {
+		return
+	}
+	return x.key, true
+}
+
+// Ceiling returns the smallest key in the set that is larger than or equal to key, if there is one.
+func (s *Ordered[K]) Ceiling(key K) (ceiling K, ok bool) {
+	if x := s.ceiling(key); x != nil {
+		return x.key, true
+	}
+	return
+}
+
+// Rank returns the number of keys in the set that are smaller than key. For keys in the set
+// this is their index in the sorted order.
+func (s *Ordered[K]) Rank(key K) int {
+	if s.len == 0 {
+		return 0
+	}
+	rank := 0
+	x := s.head
+	for l := s.level - 1; l >= 0; l-- {
+		for x.next[l].node != nil && cmp.Less(x.next[l].node.key, key) {
+			rank += x.next[l].span
+			x = x.next[l].node
+		}
+	}
+	return rank
+}
+
+// Select returns the key with the given rank, i.e. the i-th smallest key counting from 0.
+func (s *Ordered[K]) Select(i int) K {
+	if i < 0 || i >= s.len {
+		panic("set: Select() called with i out of range")
+	}
+	// The head has rank 0, the smallest key rank 1.
+	rank := 0
+	x := s.head
+	for l := s.level - 1; l >= 0; l-- {
+		for x.next[l].node != nil && rank+x.next[l].span <= i+1 {
+			rank += x.next[l].span
+			x = x.next[l].node
+		}
+	}
+	return x.key
+}
+
+// Equal checks if the sets contain the same keys.
+func (s *Ordered[K]) Equal(other *Ordered[K]) bool {
+	// The same set is always equal to itself.
+	if s == other {
+		return true
+	}
+	if s.len != other.len {
+		return false
+	}
+	for x, y := s.first(), other.first(); x != nil; x, y = x.next[0].node, y.next[0].node {
+		if cmp.Compare(x.key, y.key) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns the union of all the sets: ⋃(s, other, others) = s ∪ other ∪ others[0] ∪ others[1] ...
+func (s *Ordered[K]) Union(other *Ordered[K], others ...*Ordered[K]) *Ordered[K] {
+	// The union of a set with itself is the set.
+	if len(others) == 0 && s == other {
+		return s.Copy()
+	}
+
+	resultset := mergeOrdered(s, other, true, true, true)
+	for i := range others {
+		resultset = mergeOrdered(resultset, others[i], true, true, true)
+	}
+	return resultset
+}
+
+// Intersection returns the intersection of all the sets: ⋂(s, other, others) = s ∩ other ∩ others[0] ∩ others[1] ...
+func (s *Ordered[K]) Intersection(other *Ordered[K], others ...*Ordered[K]) *Ordered[K] {
+	// The intersection of a set with itself is the set.
+	if len(others) == 0 && s == other {
+		return s.Copy()
+	}
+
+	resultset := mergeOrdered(s, other, false, true, false)
+	for i := range others {
+		resultset = mergeOrdered(resultset, others[i], false, true, false)
+	}
+	return resultset
+}
+
+// Difference returns the difference of all the sets: s ∖ other ∖ others[0] ∖ others[1] ...
+func (s *Ordered[K]) Difference(other *Ordered[K], others ...*Ordered[K]) *Ordered[K] {
+	// The difference of a set with itself is the empty set.
+	if s == other {
+		return &Ordered[K]{}
+	}
+
+	resultset := mergeOrdered(s, other, true, false, false)
+	for i := range others {
+		resultset = mergeOrdered(resultset, others[i], true, false, false)
+	}
+	return resultset
+}
+
+// SymmetricDifference returns the difference between the union and intersection
+// of all the sets: ⋃(s, other, others) ∖ ⋂(s, other, others).
+func (s *Ordered[K]) SymmetricDifference(other *Ordered[K], others ...*Ordered[K]) *Ordered[K] {
+	// The symmetric difference of a set with itself is the empty set.
+	if len(others) == 0 && s == other {
+		return &Ordered[K]{}
+	}
+
+	if len(others) == 0 {
+		return mergeOrdered(s, other, true, false, true)
+	}
+	return s.Union(other, others...).Difference(s.Intersection(other, others...))
+}
+
+// mergeOrdered walks both sets in order at the same time and builds a new set out of the keys
+// that are only in a, in both sets or only in b, as selected.
+func mergeOrdered[K cmp.Ordered](a, b *Ordered[K], onlyA, both, onlyB bool) *Ordered[K] {
+	r := newOrderedBuilder[K]()
+	x, y := a.first(), b.first()
+	for x != nil && y != nil {
+		switch c := cmp.Compare(x.key, y.key); {
+		case c < 0:
+			if onlyA {
+				r.add(x.key)
+			}
+			x = x.next[0].node
+		case c > 0:
+			if onlyB {
+				r.add(y.key)
+			}
+			y = y.next[0].node
+		default:
+			if both {
+				r.add(x.key)
+			}
+			x, y = x.next[0].node, y.next[0].node
+		}
+	}
+	for ; x != nil && onlyA; x = x.next[0].node {
+		r.add(x.key)
+	}
+	for ; y != nil && onlyB; y = y.next[0].node {
+		r.add(y.key)
+	}
+	return r.done()
+}
+
+func (s *Ordered[K]) init() {
+	if s.head == nil {
+		s.head = &node[K]{next: make([]link[K], maxLevel)}
+		s.level = 1
+		s.rng = rand.Uint64() | 1
+	}
+}
+
+func (s *Ordered[K]) first() *node[K] {
+	if s.head == nil {
+		return nil
+	}
+	return s.head.next[0].node
+}
+
+// ceiling returns the first node with a key larger than or equal to key.
+func (s *Ordered[K]) ceiling(key K) *node[K] {
+	if s.len == 0 {
+		return nil
+	}
+	x := s.head
+	for l := s.level - 1; l >= 0; l-- {
+		for x.next[l].node != nil && cmp.Less(x.next[l].node.key, key) {
+			x = x.next[l].node
+		}
+	}
+	return x.next[0].node
+}
+
+func (s *Ordered[K]) has(key K) bool {
+	x := s.ceiling(key)
+	return x != nil && cmp.Compare(x.key, key) == 0
+}
+
+// search finds, for every level, the last node with a key smaller than key and its rank.
+func (s *Ordered[K]) search(key K, update *[maxLevel]*node[K], rank *[maxLevel]int) {
+	r := 0
+	x := s.head
+	for l := s.level - 1; l >= 0; l-- {
+		for x.next[l].node != nil && cmp.Less(x.next[l].node.key, key) {
+			r += x.next[l].span
+			x = x.next[l].node
+		}
+		update[l] = x
+		rank[l] = r
+	}
+}
+
+func (s *Ordered[K]) add(key K) {
+	s.init()
+	var update [maxLevel]*node[K]
+	var rank [maxLevel]int
+	s.search(key, &update, &rank)
+	if x := update[0].next[0].node; x != nil && cmp.Compare(x.key, key) == 0 {
+		return
+	}
+
+	level := s.randomLevel()
+	for l := s.level; l < level; l++ {
+		update[l] = s.head
+		rank[l] = 0
+		s.head.next[l].span = s.len
+	}
+	s.level = max(s.level, level)
+
+	x := &node[K]{key: key, next: make([]link[K], level)}
+	for l := 0; l < level; l++ {
+		prev := &update[l].next[l]
+		x.next[l] = link[K]{node: prev.node, span: prev.span - (rank[0] - rank[l])}
+		*prev = link[K]{node: x, span: rank[0] - rank[l] + 1}
+	}
+	// Links above the new node now skip over one more key.
+	for l := level; l < s.level; l++ {
+		update[l].next[l].span++
+	}
+	s.len++
+}
+
+func (s *Ordered[K]) del(key K) {
+	if s.len == 0 {
+		return
+	}
+	var update [maxLevel]*node[K]
+	var rank [maxLevel]int
+	s.search(key, &update, &rank)
+	x := update[0].next[0].node
+	if x == nil || cmp.Compare(x.key, key) != 0 {
+		return
+	}
+
+	for l := 0; l < s.level; l++ {
+		prev := &update[l].next[l]
+		if prev.node == x {
+			*prev = link[K]{node: x.next[l].node, span: prev.span + x.next[l].span - 1}
+		} else {
+			prev.span--
+		}
+	}
+	for s.level > 1 && s.head.next[s.level-1].node == nil {
+		s.level--
+	}
+	s.len--
+}
+
+// randomLevel picks the height of a new node, adding each level with probability 1/4.
+func (s *Ordered[K]) randomLevel() int {
+	// xorshift64
+	s.rng ^= s.rng << 13
+	s.rng ^= s.rng >> 7
+	s.rng ^= s.rng << 17
+	return min(1+bits.TrailingZeros64(s.rng)/2, maxLevel)
+}
+
+// orderedBuilder creates an ordered set out of keys that are added in increasing order,
+// appending every key to the end of the skip list without searching.
+type orderedBuilder[K cmp.Ordered] struct {
+	s    *Ordered[K]
+	last [maxLevel]*node[K]
+	rank [maxLevel]int
+}
+
+func newOrderedBuilder[K cmp.Ordered]() *orderedBuilder[K] {
+	b := &orderedBuilder[K]{s: &Ordered[K]{}}
+	b.s.init()
+	for l := range b.last {
+		b.last[l] = b.s.head
+	}
+	return b
+}
+
+func (b *orderedBuilder[K]) add(key K) {
+	s := b.s
+	level := s.randomLevel()
+	s.level = max(s.level, level)
+	s.len++
+	x := &node[K]{key: key, next: make([]link[K], level)}
+	for l := 0; l < level; l++ {
+		b.last[l].next[l] = link[K]{node: x, span: s.len - b.rank[l]}
+		b.last[l] = x
+		b.rank[l] = s.len
+	}
+}
+
+func (b *orderedBuilder[K]) done() *Ordered[K] {
+	for l := range b.last {
+		b.last[l].next[l].span = b.s.len - b.rank[l]
+	}
+	return b.s
+}
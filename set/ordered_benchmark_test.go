@@ -0,0 +1,53 @@
+package set
+
+import (
+	"testing"
+)
+
+var (
+	randomOrderedA_1000 = NewOrdered(randomKeys_1000...)
+	randomOrderedX_1000 = NewOrdered(randomKeyArray(1000)...)
+)
+
+func BenchmarkNewOrdered(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewOrdered(randomKeys_1000...)
+	}
+}
+
+func BenchmarkOrdered_Add(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var s Ordered[tkey]
+		s.Add(0, randomKeys_1000...)
+	}
+}
+
+func BenchmarkOrdered_Contains(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomOrderedA_1000.Contains(randomKeys_1000[0], randomKeys_1000...)
+	}
+}
+
+func BenchmarkOrdered_Rank(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomOrderedA_1000.Rank(randomKeys_1000[i%1000])
+	}
+}
+
+func BenchmarkOrdered_Union(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomOrderedA_1000.Union(randomOrderedX_1000)
+	}
+}
+
+func BenchmarkOrdered_Intersection(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomOrderedA_1000.Intersection(randomOrderedX_1000)
+	}
+}
+
+func BenchmarkOrdered_Difference(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomOrderedA_1000.Difference(randomOrderedX_1000)
+	}
+}
@@ -0,0 +1,227 @@
+package set
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkSkipList verifies that the links on every level are sorted and span the right number of keys.
+func checkSkipList(t *testing.T, s *Ordered[E]) {
+	t.Helper()
+	if s.head == nil {
+		assert.Zero(t, s.len)
+		return
+	}
+	ranks := map[*node[E]]int{s.head: 0}
+	i := 0
+	for x := s.first(); x != nil; x = x.next[0].node {
+		i++
+		ranks[x] = i
+	}
+	assert.Equal(t, s.len, i)
+	for l := 0; l < s.level; l++ {
+		for x := s.head; x != nil; x = x.next[l].node {
+			next := x.next[l]
+			if next.node == nil {
+				assert.Equal(t, s.len-ranks[x], next.span, "span to the end on level %d", l)
+			} else {
+				assert.Equal(t, ranks[next.node]-ranks[x], next.span, "span on level %d", l)
+				if x != s.head {
+					assert.Less(t, x.key, next.node.key)
+				}
+			}
+		}
+	}
+}
+
+var o1 = NewOrdered[E](0, 1, 2, 3, 4)
+var o2 = NewOrdered[E](3, 4, 5, 6, 7)
+var o3 = NewOrdered[E](6, 7, 8, 9, 10)
+
+func TestNewOrdered(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected []E, s *Ordered[E]) {
+		checkSkipList(t, s)
+		assert.Equal(t, expected, s.Elements())
+		assert.Equal(t, len(expected), s.Len())
+	}
+
+	var zero Ordered[E]
+	t.Run("zero", func(t *testing.T) { check(t, []E{}, &zero) })
+	t.Run("none", func(t *testing.T) { check(t, []E{}, NewOrdered[E]()) })
+	t.Run("{1}", func(t *testing.T) { check(t, []E{1}, NewOrdered[E](1)) })
+	t.Run("{4,2,0,2,1,3}", func(t *testing.T) { check(t, []E{0, 1, 2, 3, 4}, NewOrdered[E](4, 2, 0, 2, 1, 3)) })
+}
+
+func TestOrdered_AddDel(t *testing.T) {
+	t.Parallel()
+
+	var s Ordered[E]
+	s.Add(3, 1, 2, 1)
+	checkSkipList(t, &s)
+	assert.Equal(t, []E{1, 2, 3}, s.Elements())
+	assert.True(t, s.Contains(1, 2, 3))
+	assert.False(t, s.Contains(1, 4))
+
+	s.Del(2, 5)
+	checkSkipList(t, &s)
+	assert.Equal(t, []E{1, 3}, s.Elements())
+	s.Del(1, 3)
+	checkSkipList(t, &s)
+	assert.Zero(t, s.Len())
+	assert.False(t, s.Contains(1))
+
+	var zero Ordered[E]
+	zero.Del(1)
+	assert.False(t, zero.Contains(1))
+}
+
+func TestOrdered_Random(t *testing.T) {
+	t.Parallel()
+
+	var s Ordered[E]
+	model := New[E]()
+	for range 2000 {
+		k := rand.IntN(500)
+		if rand.IntN(3) == 0 {
+			s.Del(k)
+			model.Del(k)
+		} else {
+			s.Add(k)
+			model.Add(k)
+		}
+	}
+	checkSkipList(t, &s)
+	sorted := model.Elements()
+	slices.Sort(sorted)
+	assert.Equal(t, sorted, s.Elements())
+	for i, k := range sorted {
+		assert.Equal(t, k, s.Select(i))
+		assert.Equal(t, i, s.Rank(k))
+	}
+	assert.True(t, s.Equal(s.Copy()))
+	checkSkipList(t, s.Copy())
+}
+
+func TestOrdered_MinMax(t *testing.T) {
+	t.Parallel()
+
+	var zero Ordered[E]
+	_, ok := zero.Min()
+	assert.False(t, ok)
+	_, ok = zero.Max()
+	assert.False(t, ok)
+
+	k, ok := o2.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 3, k)
+	k, ok = o2.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 7, k)
+}
+
+func TestOrdered_FloorCeiling(t *testing.T) {
+	t.Parallel()
+
+	s := NewOrdered[E](10, 20, 30)
+	check := func(t *testing.T, key E, floor E, floorOk bool, ceiling E, ceilingOk bool) {
+		f, ok := s.Floor(key)
+		assert.Equal(t, floorOk, ok)
+		assert.Equal(t, floor, f)
+		c, ok := s.Ceiling(key)
+		assert.Equal(t, ceilingOk, ok)
+		assert.Equal(t, ceiling, c)
+	}
+	t.Run("5", func(t *testing.T) { check(t, 5, 0, false, 10, true) })
+	t.Run("10", func(t *testing.T) { check(t, 10, 10, true, 10, true) })
+	t.Run("15", func(t *testing.T) { check(t, 15, 10, true, 20, true) })
+	t.Run("30", func(t *testing.T) { check(t, 30, 30, true, 30, true) })
+	t.Run("35", func(t *testing.T) { check(t, 35, 30, true, 0, false) })
+
+	var zero Ordered[E]
+	_, ok := zero.Floor(1)
+	assert.False(t, ok)
+	_, ok = zero.Ceiling(1)
+	assert.False(t, ok)
+}
+
+func TestOrdered_Range(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected []E, s *Ordered[E], lo, hi E) {
+		assert.Equal(t, expected, slices.Collect(s.Range(lo, hi)))
+	}
+	t.Run("o1,1,3", func(t *testing.T) { check(t, []E{1, 2}, o1, 1, 3) })
+	t.Run("o1,-5,50", func(t *testing.T) { check(t, []E{0, 1, 2, 3, 4}, o1, -5, 50) })
+	t.Run("o1,3,3", func(t *testing.T) { check(t, nil, o1, 3, 3) })
+	t.Run("o1,5,9", func(t *testing.T) { check(t, nil, o1, 5, 9) })
+	t.Run("zero", func(t *testing.T) { check(t, nil, &Ordered[E]{}, 0, 9) })
+	t.Run("break", func(t *testing.T) {
+		for k := range o1.Range(0, 5) {
+			assert.Equal(t, 0, k)
+			break
+		}
+	})
+	assert.Equal(t, []E{6, 7, 8, 9, 10}, slices.Collect(o3.All()))
+}
+
+func TestOrdered_RankSelect(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, o2.Rank(0))
+	assert.Equal(t, 0, o2.Rank(3))
+	assert.Equal(t, 2, o2.Rank(5))
+	assert.Equal(t, 5, o2.Rank(100))
+	assert.Equal(t, 0, (&Ordered[E]{}).Rank(1))
+	assert.Equal(t, 3, o2.Select(0))
+	assert.Equal(t, 7, o2.Select(4))
+	assert.PanicsWithValue(t, "set: Select() called with i out of range", func() { o2.Select(5) })
+	assert.PanicsWithValue(t, "set: Select() called with i out of range", func() { o2.Select(-1) })
+}
+
+func TestOrdered_Equal(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, o1.Equal(o1))
+	assert.True(t, o1.Equal(NewOrdered[E](4, 3, 2, 1, 0)))
+	assert.False(t, o1.Equal(o2))
+	assert.False(t, o1.Equal(NewOrdered[E](0, 1, 2, 3)))
+	assert.True(t, NewOrdered[E]().Equal(&Ordered[E]{}))
+}
+
+func TestOrdered_Algebra(t *testing.T) {
+	t.Parallel()
+
+	null := &Ordered[E]{}
+	check := func(t *testing.T, expected []E, s *Ordered[E]) {
+		checkSkipList(t, s)
+		assert.Equal(t, expected, s.Elements())
+	}
+	t.Run("union", func(t *testing.T) {
+		check(t, []E{0, 1, 2, 3, 4, 5, 6, 7}, o1.Union(o2))
+		check(t, []E{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, o1.Union(o3, o2))
+		check(t, []E{0, 1, 2, 3, 4}, o1.Union(null))
+		check(t, []E{0, 1, 2, 3, 4}, o1.Union(o1))
+	})
+	t.Run("intersection", func(t *testing.T) {
+		check(t, []E{3, 4}, o1.Intersection(o2))
+		check(t, []E{}, o1.Intersection(o2, o3))
+		check(t, []E{}, null.Intersection(o1))
+		check(t, []E{0, 1, 2, 3, 4}, o1.Intersection(o1))
+	})
+	t.Run("difference", func(t *testing.T) {
+		check(t, []E{0, 1, 2}, o1.Difference(o2))
+		check(t, []E{5}, o2.Difference(o1, o3))
+		check(t, []E{0, 1, 2, 3, 4}, o1.Difference(null))
+		check(t, []E{}, o1.Difference(o1))
+	})
+	t.Run("symmetric difference", func(t *testing.T) {
+		check(t, []E{0, 1, 2, 5, 6, 7}, o1.SymmetricDifference(o2))
+		check(t, []E{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, o1.SymmetricDifference(o2, o3))
+		check(t, []E{}, o1.SymmetricDifference(o1))
+	})
+}
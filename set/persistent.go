@@ -0,0 +1,397 @@
+package set
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+const (
+	// hamtBits is the number of hash bits used on every level of the trie.
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+	// Nodes at hamtMaxShift have used up all the hash bits and hold keys with colliding hashes.
+	hamtMaxShift = 64
+)
+
+// Persistent is an immutable set. Adding or removing a key returns a new version of the set,
+// which shares most of its memory with the old one, so versions are cheap to keep and can be
+// passed between goroutines without copying. It is backed by a hash array mapped trie (HAMT),
+// so most operations take O(log n) time. The zero value is an empty set ready to use.
+type Persistent[K comparable] struct {
+	root *hamtNode[K]
+	len  int
+}
+
+// hamtNode is a node of the trie. Every bit set in the bitmap stands for an entry, in order.
+// The trie is kept canonical: a node below the root always holds at least two keys, so any two
+// sets with the same keys have the same shape, no matter how they were built.
+type hamtNode[K comparable] struct {
+	bitmap  uint32
+	entries []hamtEntry[K]
+	// owner is the builder that is allowed to modify the node in place.
+	owner *hamtOwner
+}
+
+// hamtEntry is either a key with its hash or, if node isn't nil, a sub-trie.
+type hamtEntry[K comparable] struct {
+	key  K
+	hash uint64
+	node *hamtNode[K]
+}
+
+// hamtOwner identifies a builder. It must not be an empty struct, so that every owner has
+// a different address.
+type hamtOwner struct {
+	_ byte
+}
+
+// PersistentBuilder builds persistent sets in place, without creating a new version for every
+// change, which makes bulk changes much faster. The zero value is an empty builder ready to use.
+// It must not be used concurrently.
+type PersistentBuilder[K comparable] struct {
+	root  *hamtNode[K]
+	len   int
+	owner *hamtOwner
+}
+
+// NewPersistent creates a new persistent set that contains all the given keys.
+func NewPersistent[K comparable](keys ...K) *Persistent[K] {
+	var b PersistentBuilder[K]
+	for i := range keys {
+		b.add(keys[i])
+	}
+	return b.Persistent()
+}
+
+// Len returns the number of keys in the set.
+func (s *Persistent[K]) Len() int {
+	return s.len
+}
+
+// Elements returns all the keys in the set in no particular order.
+func (s *Persistent[K]) Elements() []K {
+	keys := make([]K, 0, s.len)
+	for k := range s.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// All returns an iterator over all the keys in the set in no particular order.
+func (s *Persistent[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		if s.root != nil {
+			s.root.all(yield)
+		}
+	}
+}
+
+// Contains checks if the set contains all of the given keys.
+func (s *Persistent[K]) Contains(key K, keys ...K) bool {
+	if !s.has(key) {
+		return false
+	}
+	for i := range keys {
+		if !s.has(keys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// With returns a version of the set that also contains key.
+func (s *Persistent[K]) With(key K) *Persistent[K] {
+	root := s.root
+	if root == nil {
+		root = &hamtNode[K]{}
+	}
+	root, added := root.with(key, hashKey(key), 0, nil)
+	if !added {
+		return s
+	}
+	return &Persistent[K]{root: root, len: s.len + 1}
+}
+
+// Without returns a version of the set that doesn't contain key.
+func (s *Persistent[K]) Without(key K) *Persistent[K] {
+	// An empty set contains no keys.
+	if s.len == 0 {
+		return s
+	}
+
+	root, removed := s.root.without(key, hashKey(key), 0, nil)
+	if !removed {
+		return s
+	}
+	return &Persistent[K]{root: root, len: s.len - 1}
+}
+
+// Builder returns a builder that starts with the keys of the set. Changes made by the builder
+// never affect the set.
+func (s *Persistent[K]) Builder() *PersistentBuilder[K] {
+	return &PersistentBuilder[K]{root: s.root, len: s.len}
+}
+
+// Equal checks if the sets contain the same keys. Versions of a set share most of their
+// memory, which doesn't need to be compared, so it is fast for sets derived from each other.
+func (s *Persistent[K]) Equal(other *Persistent[K]) bool {
+	if s.len != other.len {
+		return false
+	}
+	if s.len == 0 {
+		return true
+	}
+	return s.root.equal(other.root, 0)
+}
+
+func (s *Persistent[K]) has(key K) bool {
+	return s.root != nil && s.root.has(key, hashKey(key), 0)
+}
+
+// Len returns the number of keys in the builder.
+func (b *PersistentBuilder[K]) Len() int {
+	return b.len
+}
+
+// Contains checks if the builder contains all of the given keys.
+func (b *PersistentBuilder[K]) Contains(key K, keys ...K) bool {
+	s := Persistent[K]{root: b.root, len: b.len}
+	return s.Contains(key, keys...)
+}
+
+// Add inserts keys into the builder.
+func (b *PersistentBuilder[K]) Add(key K, keys ...K) {
+	b.add(key)
+	for i := range keys {
+		b.add(keys[i])
+	}
+}
+
+// Del removes keys from the builder.
+func (b *PersistentBuilder[K]) Del(key K, keys ...K) {
+	b.del(key)
+	for i := range keys {
+		b.del(keys[i])
+	}
+}
+
+// Persistent returns a persistent set with the keys of the builder. The builder can still
+// be used afterwards, further changes never affect the returned set.
+func (b *PersistentBuilder[K]) Persistent() *Persistent[K] {
+	// Give up the ownership of all the nodes, they now belong to the returned set.
+	b.owner = nil
+	return &Persistent[K]{root: b.root, len: b.len}
+}
+
+func (b *PersistentBuilder[K]) add(key K) {
+	if b.owner == nil {
+		b.owner = &hamtOwner{}
+	}
+	root := b.root
+	if root == nil {
+		root = &hamtNode[K]{owner: b.owner}
+	}
+	root, added := root.with(key, hashKey(key), 0, b.owner)
+	b.root = root
+	if added {
+		b.len++
+	}
+}
+
+func (b *PersistentBuilder[K]) del(key K) {
+	// An empty set contains no keys.
+	if b.len == 0 {
+		return
+	}
+
+	if b.owner == nil {
+		b.owner = &hamtOwner{}
+	}
+	root, removed := b.root.without(key, hashKey(key), 0, b.owner)
+	b.root = root
+	if removed {
+		b.len--
+	}
+}
+
+// index returns the bit of the hash on the level at shift and the index of its entry.
+func (n *hamtNode[K]) index(hash uint64, shift uint) (bit uint32, i int) {
+	bit = 1 << (hash >> shift & hamtMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *hamtNode[K]) has(key K, hash uint64, shift uint) bool {
+	for shift < hamtMaxShift {
+		bit, i := n.index(hash, shift)
+		if n.bitmap&bit == 0 {
+			return false
+		}
+		e := n.entries[i]
+		if e.node == nil {
+			return e.key == key
+		}
+		n, shift = e.node, shift+hamtBits
+	}
+	return n.collides(key) >= 0
+}
+
+// collides returns the index of key in a node of colliding keys, or -1 if it's not there.
+func (n *hamtNode[K]) collides(key K) int {
+	return slices.IndexFunc(n.entries, func(e hamtEntry[K]) bool { return e.key == key })
+}
+
+// editable returns the node itself if it belongs to owner, otherwise a copy that does.
+func (n *hamtNode[K]) editable(owner *hamtOwner) *hamtNode[K] {
+	if owner != nil && n.owner == owner {
+		return n
+	}
+	return &hamtNode[K]{bitmap: n.bitmap, entries: slices.Clone(n.entries), owner: owner}
+}
+
+// with returns the node with key added and reports whether it wasn't there before.
+func (n *hamtNode[K]) with(key K, hash uint64, shift uint, owner *hamtOwner) (*hamtNode[K], bool) {
+	if shift >= hamtMaxShift {
+		if n.collides(key) >= 0 {
+			return n, false
+		}
+		m := n.editable(owner)
+		m.entries = append(m.entries, hamtEntry[K]{key: key, hash: hash})
+		return m, true
+	}
+
+	bit, i := n.index(hash, shift)
+	if n.bitmap&bit == 0 {
+		m := n.editable(owner)
+		m.bitmap |= bit
+		m.entries = slices.Insert(m.entries, i, hamtEntry[K]{key: key, hash: hash})
+		return m, true
+	}
+	e := n.entries[i]
+	var child *hamtNode[K]
+	switch {
+	case e.node != nil:
+		var added bool
+		if child, added = e.node.with(key, hash, shift+hamtBits, owner); !added {
+			return n, false
+		}
+	case e.key == key:
+		return n, false
+	default:
+		child = newHamtPair(e, hamtEntry[K]{key: key, hash: hash}, shift+hamtBits, owner)
+	}
+	m := n.editable(owner)
+	m.entries[i] = hamtEntry[K]{node: child}
+	return m, true
+}
+
+// newHamtPair creates a sub-trie with the keys of two entries.
+func newHamtPair[K comparable](e1, e2 hamtEntry[K], shift uint, owner *hamtOwner) *hamtNode[K] {
+	n := &hamtNode[K]{owner: owner}
+	if shift >= hamtMaxShift {
+		n.entries = []hamtEntry[K]{e1, e2}
+		return n
+	}
+	i1, i2 := e1.hash>>shift&hamtMask, e2.hash>>shift&hamtMask
+	n.bitmap = 1<<i1 | 1<<i2
+	switch {
+	case i1 == i2:
+		n.entries = []hamtEntry[K]{{node: newHamtPair(e1, e2, shift+hamtBits, owner)}}
+	case i1 < i2:
+		n.entries = []hamtEntry[K]{e1, e2}
+	default:
+		n.entries = []hamtEntry[K]{e2, e1}
+	}
+	return n
+}
+
+// without returns the node with key removed and reports whether it was there before.
+func (n *hamtNode[K]) without(key K, hash uint64, shift uint, owner *hamtOwner) (*hamtNode[K], bool) {
+	if shift >= hamtMaxShift {
+		i := n.collides(key)
+		if i < 0 {
+			return n, false
+		}
+		m := n.editable(owner)
+		m.entries = slices.Delete(m.entries, i, i+1)
+		return m, true
+	}
+
+	bit, i := n.index(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	e := n.entries[i]
+	if e.node == nil {
+		if e.key != key {
+			return n, false
+		}
+		m := n.editable(owner)
+		m.bitmap &^= bit
+		m.entries = slices.Delete(m.entries, i, i+1)
+		return m, true
+	}
+	child, removed := e.node.without(key, hash, shift+hamtBits, owner)
+	if !removed {
+		return n, false
+	}
+	m := n.editable(owner)
+	if len(child.entries) == 1 && child.entries[0].node == nil {
+		// Pull the last key of the sub-trie up, so that the trie stays canonical.
+		m.entries[i] = child.entries[0]
+	} else {
+		m.entries[i].node = child
+	}
+	return m, true
+}
+
+func (n *hamtNode[K]) all(yield func(K) bool) bool {
+	for _, e := range n.entries {
+		if e.node == nil {
+			if !yield(e.key) {
+				return false
+			}
+		} else if !e.node.all(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// equal checks if the sub-tries contain the same keys, skipping the nodes they share.
+func (n *hamtNode[K]) equal(other *hamtNode[K], shift uint) bool {
+	if n == other {
+		return true
+	}
+	if shift >= hamtMaxShift {
+		// Colliding keys are not in any particular order.
+		if len(n.entries) != len(other.entries) {
+			return false
+		}
+		for _, e := range n.entries {
+			if other.collides(e.key) < 0 {
+				return false
+			}
+		}
+		return true
+	}
+	if n.bitmap != other.bitmap {
+		return false
+	}
+	for i, e := range n.entries {
+		f := other.entries[i]
+		switch {
+		case (e.node == nil) != (f.node == nil):
+			return false
+		case e.node == nil:
+			if e.key != f.key {
+				return false
+			}
+		default:
+			if !e.node.equal(f.node, shift+hamtBits) {
+				return false
+			}
+		}
+	}
+	return true
+}
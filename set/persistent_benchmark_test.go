@@ -0,0 +1,57 @@
+package set
+
+import (
+	"testing"
+)
+
+var (
+	randomPersistentA_1000 = NewPersistent(randomKeys_1000...)
+	randomPersistentB_1000 = NewPersistent(randomKeys_1000...)
+)
+
+func BenchmarkNewPersistent(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewPersistent(randomKeys_1000...)
+	}
+}
+
+func BenchmarkPersistent_With(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s := NewPersistent[tkey]()
+		for _, k := range randomKeys_1000 {
+			s = s.With(k)
+		}
+	}
+}
+
+func BenchmarkPersistent_Without(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomPersistentA_1000.Without(randomKeys_1000[i%1000])
+	}
+}
+
+func BenchmarkPersistent_Contains(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomPersistentA_1000.Contains(randomKeys_1000[0], randomKeys_1000...)
+	}
+}
+
+func BenchmarkPersistent_Equal_SameObject(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomPersistentA_1000.Equal(randomPersistentA_1000)
+	}
+}
+
+func BenchmarkPersistent_Equal_SharedVersion(b *testing.B) {
+	other := randomPersistentA_1000.With(-1).Without(-1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		randomPersistentA_1000.Equal(other)
+	}
+}
+
+func BenchmarkPersistent_Equal_SameSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomPersistentA_1000.Equal(randomPersistentB_1000)
+	}
+}
@@ -0,0 +1,218 @@
+package set
+
+import (
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkPersistent verifies that the trie is canonical and holds the right number of keys.
+func checkPersistent(t *testing.T, s *Persistent[E]) {
+	t.Helper()
+	var check func(n *hamtNode[E], shift uint, root bool) int
+	check = func(n *hamtNode[E], shift uint, root bool) int {
+		if shift < hamtMaxShift {
+			assert.Equal(t, len(n.entries), bits.OnesCount32(n.bitmap))
+		}
+		keys := 0
+		for _, e := range n.entries {
+			if e.node == nil {
+				keys++
+			} else {
+				keys += check(e.node, shift+hamtBits, false)
+			}
+		}
+		if !root {
+			assert.GreaterOrEqual(t, keys, 2, "sub-trie with less than two keys")
+		}
+		return keys
+	}
+	if s.root == nil {
+		assert.Zero(t, s.len)
+		return
+	}
+	assert.Equal(t, s.len, check(s.root, 0, true))
+}
+
+func sorted(keys []E) []E {
+	keys = slices.Clone(keys)
+	slices.Sort(keys)
+	return keys
+}
+
+func TestNewPersistent(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected []E, s *Persistent[E]) {
+		checkPersistent(t, s)
+		assert.Equal(t, expected, sorted(s.Elements()))
+		assert.Equal(t, len(expected), s.Len())
+	}
+
+	var zero Persistent[E]
+	t.Run("zero", func(t *testing.T) { check(t, []E{}, &zero) })
+	t.Run("none", func(t *testing.T) { check(t, []E{}, NewPersistent[E]()) })
+	t.Run("{2,1,2,0}", func(t *testing.T) { check(t, []E{0, 1, 2}, NewPersistent[E](2, 1, 2, 0)) })
+}
+
+func TestPersistent_WithWithout(t *testing.T) {
+	t.Parallel()
+
+	var zero Persistent[E]
+	assert.Same(t, &zero, zero.Without(1))
+	v1 := zero.With(1)
+	v2 := v1.With(2)
+	v3 := v2.With(3).Without(1)
+	assert.Same(t, v2, v2.With(2))
+	assert.Same(t, v2, v2.Without(5))
+
+	assert.Zero(t, zero.Len())
+	assert.Equal(t, []E{1}, v1.Elements())
+	assert.Equal(t, []E{1, 2}, sorted(v2.Elements()))
+	assert.Equal(t, []E{2, 3}, sorted(v3.Elements()))
+	assert.True(t, v2.Contains(1, 2))
+	assert.False(t, v3.Contains(1))
+	assert.False(t, zero.Contains(1))
+	for _, s := range []*Persistent[E]{&zero, v1, v2, v3} {
+		checkPersistent(t, s)
+	}
+}
+
+func TestPersistent_Random(t *testing.T) {
+	t.Parallel()
+
+	s := NewPersistent[E]()
+	model := New[E]()
+	var versions []*Persistent[E]
+	var models []Set[E]
+	for i := range 5000 {
+		k := rand.IntN(1000)
+		if rand.IntN(3) == 0 {
+			s = s.Without(k)
+			model.Del(k)
+		} else {
+			s = s.With(k)
+			model.Add(k)
+		}
+		if i%500 == 0 {
+			versions = append(versions, s)
+			models = append(models, model.Copy())
+		}
+	}
+	checkPersistent(t, s)
+	assert.Equal(t, model, New(s.Elements()...))
+	assert.True(t, s.Equal(NewPersistent(model.Elements()...)))
+	// Old versions never change.
+	for i := range versions {
+		checkPersistent(t, versions[i])
+		assert.Equal(t, models[i], New(versions[i].Elements()...))
+	}
+}
+
+func TestPersistent_Builder(t *testing.T) {
+	t.Parallel()
+
+	var b PersistentBuilder[E]
+	b.Del(1)
+	b.Add(1, 2, 3, 4, 5)
+	b.Del(5)
+	assert.Equal(t, 4, b.Len())
+	assert.True(t, b.Contains(1, 2, 3, 4))
+	s1 := b.Persistent()
+
+	// Changing the builder afterwards doesn't change the set.
+	b.Add(6)
+	b.Del(1)
+	s2 := b.Persistent()
+	checkPersistent(t, s1)
+	checkPersistent(t, s2)
+	assert.Equal(t, []E{1, 2, 3, 4}, sorted(s1.Elements()))
+	assert.Equal(t, []E{2, 3, 4, 6}, sorted(s2.Elements()))
+
+	// Builders started from a set don't change it.
+	keys := make([]E, 1000)
+	for i := range keys {
+		keys[i] = i
+	}
+	s3 := NewPersistent(keys...)
+	b3 := s3.Builder()
+	for _, k := range keys[:500] {
+		b3.Del(k)
+	}
+	b3.Add(-1)
+	s4 := b3.Persistent()
+	checkPersistent(t, s3)
+	checkPersistent(t, s4)
+	assert.Equal(t, keys, sorted(s3.Elements()))
+	assert.Equal(t, append([]E{-1}, keys[500:]...), sorted(s4.Elements()))
+}
+
+func TestPersistent_Equal(t *testing.T) {
+	t.Parallel()
+
+	p1 := NewPersistent[E](0, 1, 2, 3, 4)
+	assert.True(t, p1.Equal(p1))
+	assert.True(t, p1.Equal(NewPersistent[E](4, 3, 2, 1, 0)))
+	assert.True(t, p1.Equal(p1.With(5).Without(5)))
+	assert.True(t, p1.Without(0).Equal(NewPersistent[E](1, 2, 3, 4)))
+	assert.False(t, p1.Equal(NewPersistent[E](0, 1, 2, 3)))
+	assert.False(t, p1.Equal(NewPersistent[E](0, 1, 2, 3, 5)))
+	assert.True(t, NewPersistent[E]().Equal(&Persistent[E]{}))
+	assert.True(t, NewPersistent[E](1).Without(1).Equal(&Persistent[E]{}))
+}
+
+func TestPersistent_Collisions(t *testing.T) {
+	t.Parallel()
+
+	// Keys with the same hash end up in a node below all the levels of the trie.
+	var root *hamtNode[E] = &hamtNode[E]{}
+	for k := range 5 {
+		var added bool
+		root, added = root.with(k, 42, 0, nil)
+		assert.True(t, added)
+	}
+	_, added := root.with(3, 42, 0, nil)
+	assert.False(t, added)
+	s := &Persistent[E]{root: root, len: 5}
+	checkPersistent(t, s)
+	assert.Equal(t, []E{0, 1, 2, 3, 4}, sorted(s.Elements()))
+	for k := range 5 {
+		assert.True(t, root.has(k, 42, 0))
+	}
+	assert.False(t, root.has(5, 42, 0))
+
+	other := &hamtNode[E]{}
+	for _, k := range []E{4, 3, 2, 1, 0} {
+		other, _ = other.with(k, 42, 0, nil)
+	}
+	assert.True(t, root.equal(other, 0))
+
+	for k := range 4 {
+		var removed bool
+		root, removed = root.without(k, 42, 0, nil)
+		assert.True(t, removed)
+	}
+	_, removed := root.without(0, 42, 0, nil)
+	assert.False(t, removed)
+	s = &Persistent[E]{root: root, len: 1}
+	checkPersistent(t, s)
+	assert.Equal(t, []E{4}, s.Elements())
+	assert.Equal(t, []hamtEntry[E]{{key: 4, hash: 42}}, root.entries)
+	assert.False(t, root.equal(other, 0))
+}
+
+func TestPersistent_All(t *testing.T) {
+	t.Parallel()
+
+	s := NewPersistent[E](0, 1, 2, 3, 4)
+	n := 0
+	for range s.All() {
+		n++
+		break
+	}
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []E{0, 1, 2, 3, 4}, sorted(slices.Collect(s.All())))
+}
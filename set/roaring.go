@@ -0,0 +1,558 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// Roaring is a compressed bitmap set of uint32 keys, well suited for large sets of sparse keys.
+// The keys are split into chunks of 2^16 by their high 16 bits, and the low 16 bits of the keys
+// in every chunk are stored in the smallest of a sorted array, a bitmap or a list of runs. The
+// zero value is an empty set ready to use. Roaring sets must not be copied by value, use Copy()
+// instead.
+type Roaring struct {
+	// keys are the sorted high 16 bits of all the chunks that are not empty.
+	keys       []uint16
+	containers []container
+}
+
+// NewRoaring creates a new roaring bitmap that contains all the given keys.
+func NewRoaring(keys ...uint32) *Roaring {
+	sorted := slices.Clone(keys)
+	slices.Sort(sorted)
+	r := &Roaring{}
+	for _, k := range slices.Compact(sorted) {
+		// Sorted keys only ever go to the last container.
+		if n := len(r.keys); n > 0 && r.keys[n-1] == uint16(k>>16) {
+			r.containers[n-1] = r.containers[n-1].add(uint16(k))
+		} else {
+			r.keys = append(r.keys, uint16(k>>16))
+			r.containers = append(r.containers, arrayContainer{uint16(k)})
+		}
+	}
+	return r
+}
+
+// RoaringFromSet creates a new roaring bitmap that contains all the keys of the set.
+func RoaringFromSet(set Set[uint32]) *Roaring {
+	return NewRoaring(set.Elements()...)
+}
+
+// ToSet creates a new Set that contains all the keys of the roaring bitmap.
+func (r *Roaring) ToSet() Set[uint32] {
+	resultset := make(Set[uint32], r.Len())
+	for k := range r.All() {
+		resultset[k] = struct{}{}
+	}
+	return resultset
+}
+
+// Len returns the number of keys in the set.
+func (r *Roaring) Len() int {
+	n := 0
+	for _, c := range r.containers {
+		n += c.len()
+	}
+	return n
+}
+
+// Copy creates a deep copy of the set.
+func (r *Roaring) Copy() *Roaring {
+	resultset := &Roaring{
+		keys:       slices.Clone(r.keys),
+		containers: make([]container, len(r.containers)),
+	}
+	for i, c := range r.containers {
+		resultset.containers[i] = c.clone()
+	}
+	return resultset
+}
+
+// Elements returns all the keys in the set in ascending order.
+func (r *Roaring) Elements() []uint32 {
+	keys := make([]uint32, 0, r.Len())
+	for k := range r.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// All returns an iterator over all the keys in the set in ascending order.
+// The set must not be modified during the iteration.
+func (r *Roaring) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for i, c := range r.containers {
+			hi := uint32(r.keys[i]) << 16
+			if !c.iterate(0, func(lo uint16) bool { return yield(hi | uint32(lo)) }) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys k in the set, such that lo <= k < hi, in ascending order.
+// The set must not be modified during the iteration.
+func (r *Roaring) Range(lo, hi uint32) iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		if lo >= hi {
+			return
+		}
+		i, _ := slices.BinarySearch(r.keys, uint16(lo>>16))
+		for ; i < len(r.keys); i++ {
+			chunk := uint32(r.keys[i]) << 16
+			if chunk >= hi {
+				return
+			}
+			from := uint16(0)
+			if chunk < lo {
+				from = uint16(lo)
+			}
+			if !r.containers[i].iterate(from, func(low uint16) bool {
+				k := chunk | uint32(low)
+				return k < hi && yield(k)
+			}) {
+				return
+			}
+		}
+	}
+}
+
+// Contains checks if the set contains all of the given keys.
+func (r *Roaring) Contains(key uint32, keys ...uint32) bool {
+	if !r.has(key) {
+		return false
+	}
+	for i := range keys {
+		if !r.has(keys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add inserts keys into the set.
+func (r *Roaring) Add(key uint32, keys ...uint32) {
+	r.add(key)
+	for i := range keys {
+		r.add(keys[i])
+	}
+}
+
+// Del removes keys from the set.
+func (r *Roaring) Del(key uint32, keys ...uint32) {
+	// An empty set contains no keys.
+	if len(r.keys) == 0 {
+		return
+	}
+
+	r.del(key)
+	for i := range keys {
+		r.del(keys[i])
+	}
+}
+
+// RunOptimize converts every chunk of the set to the representation that takes the least
+// memory, including lists of runs, which are otherwise only used when decoding a set.
+// It is best called after the set is built, as sets of long runs of consecutive keys
+// can then take much less memory.
+func (r *Roaring) RunOptimize() {
+	for i, c := range r.containers {
+		r.containers[i] = optimizeContainer(c)
+	}
+}
+
+// Equal checks if the sets contain the same keys.
+func (r *Roaring) Equal(other *Roaring) bool {
+	// The same set is always equal to itself.
+	if r == other {
+		return true
+	}
+	if !slices.Equal(r.keys, other.keys) {
+		return false
+	}
+	for i := range r.containers {
+		if !equalContainers(r.containers[i], other.containers[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns the union of all the sets: ⋃(r, other, others) = r ∪ other ∪ others[0] ∪ others[1] ...
+func (r *Roaring) Union(other *Roaring, others ...*Roaring) *Roaring {
+	// The union of a set with itself is the set.
+	if len(others) == 0 && r == other {
+		return r.Copy()
+	}
+
+	resultset := mergeRoaring(r, other, orContainers, true, true)
+	for i := range others {
+		resultset = mergeRoaring(resultset, others[i], orContainers, true, true)
+	}
+	return resultset
+}
+
+// Intersection returns the intersection of all the sets: ⋂(r, other, others) = r ∩ other ∩ others[0] ∩ others[1] ...
+func (r *Roaring) Intersection(other *Roaring, others ...*Roaring) *Roaring {
+	// The intersection of a set with itself is the set.
+	if len(others) == 0 && r == other {
+		return r.Copy()
+	}
+
+	resultset := mergeRoaring(r, other, andContainers, false, false)
+	for i := range others {
+		resultset = mergeRoaring(resultset, others[i], andContainers, false, false)
+	}
+	return resultset
+}
+
+// Difference returns the difference of all the sets: r ∖ other ∖ others[0] ∖ others[1] ...
+func (r *Roaring) Difference(other *Roaring, others ...*Roaring) *Roaring {
+	// The difference of a set with itself is the empty set.
+	if r == other {
+		return &Roaring{}
+	}
+
+	resultset := mergeRoaring(r, other, andNotContainers, true, false)
+	for i := range others {
+		resultset = mergeRoaring(resultset, others[i], andNotContainers, true, false)
+	}
+	return resultset
+}
+
+// SymmetricDifference returns the difference between the union and intersection
+// of all the sets: ⋃(r, other, others) ∖ ⋂(r, other, others).
+func (r *Roaring) SymmetricDifference(other *Roaring, others ...*Roaring) *Roaring {
+	// The symmetric difference of a set with itself is the empty set.
+	if len(others) == 0 && r == other {
+		return &Roaring{}
+	}
+
+	if len(others) == 0 {
+		return mergeRoaring(r, other, xorContainers, true, true)
+	}
+	return r.Union(other, others...).Difference(r.Intersection(other, others...))
+}
+
+// mergeRoaring walks the chunks of both sets in order at the same time and builds a new set out of
+// the chunks that are only in a and only in b, as selected, and the result of op on the chunks that
+// are in both sets.
+func mergeRoaring(a, b *Roaring, op func(x, y container) container, onlyA, onlyB bool) *Roaring {
+	r := &Roaring{}
+	push := func(key uint16, c container) {
+		if c != nil {
+			r.keys = append(r.keys, key)
+			r.containers = append(r.containers, c)
+		}
+	}
+	i, j := 0, 0
+	for i < len(a.keys) && j < len(b.keys) {
+		switch x, y := a.keys[i], b.keys[j]; {
+		case x < y:
+			if onlyA {
+				push(x, a.containers[i].clone())
+			}
+			i++
+		case x > y:
+			if onlyB {
+				push(y, b.containers[j].clone())
+			}
+			j++
+		default:
+			push(x, op(a.containers[i], b.containers[j]))
+			i, j = i+1, j+1
+		}
+	}
+	for ; i < len(a.keys) && onlyA; i++ {
+		push(a.keys[i], a.containers[i].clone())
+	}
+	for ; j < len(b.keys) && onlyB; j++ {
+		push(b.keys[j], b.containers[j].clone())
+	}
+	return r
+}
+
+// equalContainers checks if the containers hold the same values, even in different representations.
+func equalContainers(a, b container) bool {
+	if a.len() != b.len() {
+		return false
+	}
+	switch a := a.(type) {
+	case arrayContainer:
+		if b, ok := b.(arrayContainer); ok {
+			return slices.Equal(a, b)
+		}
+	case *bitmapContainer:
+		if b, ok := b.(*bitmapContainer); ok {
+			return a.words == b.words
+		}
+	case runContainer:
+		if b, ok := b.(runContainer); ok {
+			return slices.Equal(a, b)
+		}
+	}
+	// Both have the same number of values, so they are equal if one is a subset of the other.
+	return a.iterate(0, b.has)
+}
+
+func (r *Roaring) has(key uint32) bool {
+	i, found := slices.BinarySearch(r.keys, uint16(key>>16))
+	return found && r.containers[i].has(uint16(key))
+}
+
+func (r *Roaring) add(key uint32) {
+	i, found := slices.BinarySearch(r.keys, uint16(key>>16))
+	if found {
+		r.containers[i] = r.containers[i].add(uint16(key))
+		return
+	}
+	r.keys = slices.Insert(r.keys, i, uint16(key>>16))
+	r.containers = slices.Insert(r.containers, i, container(arrayContainer{uint16(key)}))
+}
+
+func (r *Roaring) del(key uint32) {
+	i, found := slices.BinarySearch(r.keys, uint16(key>>16))
+	if !found {
+		return
+	}
+	if c := r.containers[i].del(uint16(key)); c != nil {
+		r.containers[i] = c
+		return
+	}
+	r.keys = slices.Delete(r.keys, i, i+1)
+	r.containers = slices.Delete(r.containers, i, i+1)
+}
+
+// The portable roaring format is described in https://github.com/RoaringBitmap/RoaringFormatSpec.
+const (
+	roaringCookieNoRuns = 12346
+	roaringCookie       = 12347
+	// Sets with runs and less chunks than this don't store the offsets of the chunks.
+	roaringNoOffsetThreshold = 4
+)
+
+// MarshalBinary encodes the set in the portable roaring format, which can be read by
+// the roaring bitmap libraries of other languages.
+func (r *Roaring) MarshalBinary() ([]byte, error) {
+	n := len(r.keys)
+	var runFlags []byte
+	for i, c := range r.containers {
+		if _, ok := c.(runContainer); ok {
+			if runFlags == nil {
+				runFlags = make([]byte, (n+7)/8)
+			}
+			runFlags[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	var data []byte
+	if runFlags != nil {
+		data = binary.LittleEndian.AppendUint32(data, roaringCookie|uint32(n-1)<<16)
+		data = append(data, runFlags...)
+	} else {
+		data = binary.LittleEndian.AppendUint32(data, roaringCookieNoRuns)
+		data = binary.LittleEndian.AppendUint32(data, uint32(n))
+	}
+	for i, c := range r.containers {
+		data = binary.LittleEndian.AppendUint16(data, r.keys[i])
+		data = binary.LittleEndian.AppendUint16(data, uint16(c.len()-1))
+	}
+	if runFlags == nil || n >= roaringNoOffsetThreshold {
+		offset := len(data) + 4*n
+		for _, c := range r.containers {
+			data = binary.LittleEndian.AppendUint32(data, uint32(offset))
+			offset += encodedContainerSize(c)
+		}
+	}
+	for _, c := range r.containers {
+		data = appendContainer(data, c)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the keys of the set with the ones encoded in the portable roaring format.
+func (r *Roaring) UnmarshalBinary(data []byte) error {
+	d := roaringDecoder{data: data}
+	var n int
+	var runFlags []byte
+	switch cookie := d.uint32(); {
+	case d.err != nil:
+		return d.err
+	case cookie == roaringCookieNoRuns:
+		n = int(d.uint32())
+	case cookie&0xffff == roaringCookie:
+		n = int(cookie>>16) + 1
+		runFlags = d.next((n + 7) / 8)
+	default:
+		return fmt.Errorf("set: invalid roaring bitmap cookie %#x", cookie)
+	}
+	if n > 1<<16 {
+		return fmt.Errorf("set: roaring bitmap with %d chunks", n)
+	}
+
+	header := d.next(4 * n)
+	if runFlags == nil || n >= roaringNoOffsetThreshold {
+		d.next(4 * n)
+	}
+	if d.err != nil {
+		return d.err
+	}
+	keys := make([]uint16, n)
+	containers := make([]container, n)
+	for i := range n {
+		keys[i] = binary.LittleEndian.Uint16(header[4*i:])
+		if i > 0 && keys[i-1] >= keys[i] {
+			return errors.New("set: roaring bitmap chunks are not sorted")
+		}
+		size := int(binary.LittleEndian.Uint16(header[4*i+2:])) + 1
+		var c container
+		if runFlags != nil && runFlags[i/8]&(1<<(i%8)) != 0 {
+			c = d.runs()
+		} else if size <= arrayMaxLen {
+			c = d.array(size)
+		} else {
+			c = d.bitmap()
+		}
+		if d.err != nil {
+			return d.err
+		}
+		if c.len() != size {
+			return fmt.Errorf("set: roaring bitmap chunk %d has %d keys instead of %d", keys[i], c.len(), size)
+		}
+		containers[i] = c
+	}
+	r.keys = keys
+	r.containers = containers
+	return nil
+}
+
+// encodedContainerSize returns the number of bytes appendContainer adds for c.
+func encodedContainerSize(c container) int {
+	if r, ok := c.(runContainer); ok {
+		return 2 + 4*len(r)
+	}
+	if n := c.len(); n <= arrayMaxLen {
+		return 2 * n
+	}
+	return 8 * bitmapWords
+}
+
+func appendContainer(data []byte, c container) []byte {
+	if r, ok := c.(runContainer); ok {
+		data = binary.LittleEndian.AppendUint16(data, uint16(len(r)))
+		for _, iv := range r {
+			data = binary.LittleEndian.AppendUint16(data, iv.start)
+			data = binary.LittleEndian.AppendUint16(data, iv.last-iv.start)
+		}
+		return data
+	}
+	if c.len() <= arrayMaxLen {
+		c.iterate(0, func(x uint16) bool {
+			data = binary.LittleEndian.AppendUint16(data, x)
+			return true
+		})
+		return data
+	}
+	for _, w := range c.bitmap().words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data
+}
+
+// roaringDecoder reads the portable roaring format. After the first error all reads return zero values.
+type roaringDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *roaringDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.data) < n {
+		d.err = errors.New("set: roaring bitmap is truncated")
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *roaringDecoder) uint16() uint16 {
+	if b := d.next(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (d *roaringDecoder) uint32() uint32 {
+	if b := d.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *roaringDecoder) array(n int) container {
+	b := d.next(2 * n)
+	if b == nil {
+		return nil
+	}
+	a := make(arrayContainer, n)
+	for i := range a {
+		a[i] = binary.LittleEndian.Uint16(b[2*i:])
+		if i > 0 && a[i-1] >= a[i] {
+			d.err = errors.New("set: roaring bitmap array is not sorted")
+			return nil
+		}
+	}
+	return a
+}
+
+func (d *roaringDecoder) bitmap() container {
+	b := d.next(8 * bitmapWords)
+	if b == nil {
+		return nil
+	}
+	c := &bitmapContainer{}
+	for i := range c.words {
+		c.words[i] = binary.LittleEndian.Uint64(b[8*i:])
+		c.n += bits.OnesCount64(c.words[i])
+	}
+	return c
+}
+
+func (d *roaringDecoder) runs() container {
+	n := int(d.uint16())
+	b := d.next(4 * n)
+	if d.err != nil {
+		return nil
+	}
+	if n == 0 {
+		d.err = errors.New("set: roaring bitmap has an empty run container")
+		return nil
+	}
+	r := make(runContainer, 0, n)
+	for i := range n {
+		start, length := binary.LittleEndian.Uint16(b[4*i:]), binary.LittleEndian.Uint16(b[4*i+2:])
+		if int(start)+int(length) > 1<<16-1 {
+			d.err = errors.New("set: roaring bitmap run is out of range")
+			return nil
+		}
+		last := len(r) - 1
+		switch {
+		case last >= 0 && r[last].last >= start:
+			d.err = errors.New("set: roaring bitmap runs are not sorted")
+			return nil
+		case last >= 0 && r[last].last+1 == start:
+			// Merge adjacent runs, so that there is one run per consecutive keys.
+			r[last].last = start + length
+		default:
+			r = append(r, interval{start, start + length})
+		}
+	}
+	return r
+}
@@ -0,0 +1,87 @@
+package set
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomRoaringKeyArray(size uint, limit uint32) []uint32 {
+	a := make([]uint32, 0, size)
+	for i := uint(0); i < size; i++ {
+		a = append(a, rand.Uint32()%limit)
+	}
+	return a
+}
+
+var (
+	randomRoaringKeys_1000 = randomRoaringKeyArray(1000, 1<<32-1)
+	randomRoaringA_1000    = NewRoaring(randomRoaringKeys_1000...)
+	randomRoaringX_1000    = NewRoaring(randomRoaringKeyArray(1000, 1<<32-1)...)
+	denseRoaringA          = NewRoaring(randomRoaringKeyArray(50000, 1<<20)...)
+	denseRoaringX          = NewRoaring(randomRoaringKeyArray(50000, 1<<20)...)
+)
+
+func BenchmarkNewRoaring(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewRoaring(randomRoaringKeys_1000...)
+	}
+}
+
+func BenchmarkRoaring_Add(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var r Roaring
+		r.Add(0, randomRoaringKeys_1000...)
+	}
+}
+
+func BenchmarkRoaring_Contains(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomRoaringA_1000.Contains(randomRoaringKeys_1000[0], randomRoaringKeys_1000...)
+	}
+}
+
+func BenchmarkRoaring_All(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for range denseRoaringA.All() {
+		}
+	}
+}
+
+func BenchmarkRoaring_Union(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		randomRoaringA_1000.Union(randomRoaringX_1000)
+	}
+}
+
+func BenchmarkRoaring_Union_Dense(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		denseRoaringA.Union(denseRoaringX)
+	}
+}
+
+func BenchmarkRoaring_Intersection_Dense(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		denseRoaringA.Intersection(denseRoaringX)
+	}
+}
+
+func BenchmarkRoaring_Difference_Dense(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		denseRoaringA.Difference(denseRoaringX)
+	}
+}
+
+func BenchmarkRoaring_MarshalBinary(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = denseRoaringA.MarshalBinary()
+	}
+}
+
+func BenchmarkRoaring_UnmarshalBinary(b *testing.B) {
+	data, _ := denseRoaringA.MarshalBinary()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var r Roaring
+		_ = r.UnmarshalBinary(data)
+	}
+}
@@ -0,0 +1,443 @@
+package set
+
+import (
+	"math/bits"
+	"slices"
+)
+
+const (
+	// arrayMaxLen is the largest number of values kept in an array container,
+	// larger containers take less memory as bitmaps.
+	arrayMaxLen = 4096
+	// bitmapWords is the number of words in a bitmap container.
+	bitmapWords = 1 << 16 / wordSize
+)
+
+// container holds the low 16 bits of all the keys in a Roaring bitmap that share the high 16 bits.
+// Containers are never empty. Methods that modify a container return the container that should
+// take its place, which can be of a different kind or nil if it became empty.
+type container interface {
+	len() int
+	has(x uint16) bool
+	add(x uint16) container
+	del(x uint16) container
+	clone() container
+	// iterate yields all the values that are >= from in ascending order and reports
+	// whether the iteration should continue.
+	iterate(from uint16, yield func(uint16) bool) bool
+	// bitmap returns a bitmap with the same values, it must not be modified.
+	bitmap() *bitmapContainer
+	// runs returns the number of runs of consecutive values.
+	runs() int
+}
+
+// arrayContainer is a sorted array of values, used for sparse containers.
+type arrayContainer []uint16
+
+// bitmapContainer is a bitmap of all the 2^16 possible values, used for dense containers.
+type bitmapContainer struct {
+	words [bitmapWords]uint64
+	n     int
+}
+
+// runContainer is a sorted list of runs of consecutive values, used for containers with long runs.
+type runContainer []interval
+
+// interval is a run of consecutive values from start to last, inclusive.
+type interval struct {
+	start, last uint16
+}
+
+func (a arrayContainer) len() int {
+	return len(a)
+}
+
+func (a arrayContainer) has(x uint16) bool {
+	_, found := slices.BinarySearch(a, x)
+	return found
+}
+
+func (a arrayContainer) add(x uint16) container {
+	i, found := slices.BinarySearch(a, x)
+	if found {
+		return a
+	}
+	if len(a) == arrayMaxLen {
+		b := a.bitmap()
+		return b.add(x)
+	}
+	return slices.Insert(a, i, x)
+}
+
+func (a arrayContainer) del(x uint16) container {
+	i, found := slices.BinarySearch(a, x)
+	if !found {
+		return a
+	}
+	if len(a) == 1 {
+		return nil
+	}
+	return slices.Delete(a, i, i+1)
+}
+
+func (a arrayContainer) clone() container {
+	return slices.Clone(a)
+}
+
+func (a arrayContainer) iterate(from uint16, yield func(uint16) bool) bool {
+	i, _ := slices.BinarySearch(a, from)
+	for _, x := range a[i:] {
+		if !yield(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a arrayContainer) bitmap() *bitmapContainer {
+	b := &bitmapContainer{n: len(a)}
+	for _, x := range a {
+		b.words[x/wordSize] |= 1 << (x % wordSize)
+	}
+	return b
+}
+
+func (a arrayContainer) runs() int {
+	n := 0
+	for i := range a {
+		if i == 0 || a[i-1]+1 != a[i] {
+			n++
+		}
+	}
+	return n
+}
+
+func (b *bitmapContainer) len() int {
+	return b.n
+}
+
+func (b *bitmapContainer) has(x uint16) bool {
+	return b.words[x/wordSize]&(1<<(x%wordSize)) != 0
+}
+
+func (b *bitmapContainer) add(x uint16) container {
+	if !b.has(x) {
+		b.words[x/wordSize] |= 1 << (x % wordSize)
+		b.n++
+	}
+	return b
+}
+
+func (b *bitmapContainer) del(x uint16) container {
+	if !b.has(x) {
+		return b
+	}
+	b.words[x/wordSize] &^= 1 << (x % wordSize)
+	b.n--
+	if b.n <= arrayMaxLen {
+		return b.array()
+	}
+	return b
+}
+
+func (b *bitmapContainer) clone() container {
+	c := *b
+	return &c
+}
+
+func (b *bitmapContainer) iterate(from uint16, yield func(uint16) bool) bool {
+	wi := int(from / wordSize)
+	w := b.words[wi] &^ (1<<(from%wordSize) - 1)
+	for {
+		for w != 0 {
+			if !yield(uint16(wi*wordSize + bits.TrailingZeros64(w))) {
+				return false
+			}
+			w &= w - 1
+		}
+		if wi++; wi == bitmapWords {
+			return true
+		}
+		w = b.words[wi]
+	}
+}
+
+func (b *bitmapContainer) bitmap() *bitmapContainer {
+	return b
+}
+
+func (b *bitmapContainer) runs() int {
+	n := 0
+	for i, w := range b.words {
+		// Count the starts of runs: set bits whose lower neighbour isn't set.
+		carry := uint64(0)
+		if i > 0 {
+			carry = b.words[i-1] >> (wordSize - 1)
+		}
+		n += bits.OnesCount64(w &^ (w<<1 | carry))
+	}
+	return n
+}
+
+// array converts a sparse bitmap to an array container.
+func (b *bitmapContainer) array() arrayContainer {
+	a := make(arrayContainer, 0, b.n)
+	b.iterate(0, func(x uint16) bool {
+		a = append(a, x)
+		return true
+	})
+	return a
+}
+
+// optimize returns the smaller of the bitmap and array representations, or nil if it's empty.
+func (b *bitmapContainer) optimize() container {
+	switch {
+	case b.n == 0:
+		return nil
+	case b.n <= arrayMaxLen:
+		return b.array()
+	default:
+		return b
+	}
+}
+
+func (r runContainer) len() int {
+	n := 0
+	for _, iv := range r {
+		n += int(iv.last-iv.start) + 1
+	}
+	return n
+}
+
+// search returns the index of the first run that ends at or after x.
+func (r runContainer) search(x uint16) int {
+	i, _ := slices.BinarySearchFunc(r, x, func(iv interval, x uint16) int {
+		return int(iv.last) - int(x)
+	})
+	return i
+}
+
+func (r runContainer) has(x uint16) bool {
+	i := r.search(x)
+	return i < len(r) && r[i].start <= x
+}
+
+func (r runContainer) add(x uint16) container {
+	i := r.search(x)
+	if i < len(r) && r[i].start <= x {
+		return r
+	}
+	joinPrev := i > 0 && r[i-1].last+1 == x
+	joinNext := i < len(r) && x+1 == r[i].start
+	switch {
+	case joinPrev && joinNext:
+		r[i-1].last = r[i].last
+		return slices.Delete(r, i, i+1)
+	case joinPrev:
+		r[i-1].last = x
+	case joinNext:
+		r[i].start = x
+	default:
+		return slices.Insert(r, i, interval{x, x})
+	}
+	return r
+}
+
+func (r runContainer) del(x uint16) container {
+	i := r.search(x)
+	if i == len(r) || r[i].start > x {
+		return r
+	}
+	switch iv := r[i]; {
+	case iv.start == iv.last:
+		if len(r) == 1 {
+			return nil
+		}
+		return slices.Delete(r, i, i+1)
+	case x == iv.start:
+		r[i].start++
+	case x == iv.last:
+		r[i].last--
+	default:
+		r[i].last = x - 1
+		return slices.Insert(r, i+1, interval{x + 1, iv.last})
+	}
+	return r
+}
+
+func (r runContainer) clone() container {
+	return slices.Clone(r)
+}
+
+func (r runContainer) iterate(from uint16, yield func(uint16) bool) bool {
+	for _, iv := range r[r.search(from):] {
+		// Loop with an int to not overflow at the end of the last run.
+		for x := int(max(iv.start, from)); x <= int(iv.last); x++ {
+			if !yield(uint16(x)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r runContainer) bitmap() *bitmapContainer {
+	b := &bitmapContainer{}
+	for _, iv := range r {
+		for x := int(iv.start); x <= int(iv.last); x++ {
+			b.words[x/wordSize] |= 1 << (x % wordSize)
+		}
+		b.n += int(iv.last-iv.start) + 1
+	}
+	return b
+}
+
+func (r runContainer) runs() int {
+	return len(r)
+}
+
+// toRuns converts any container to a run container.
+func toRuns(c container) runContainer {
+	r := make(runContainer, 0, c.runs())
+	c.iterate(0, func(x uint16) bool {
+		if n := len(r); n > 0 && r[n-1].last+1 == x {
+			r[n-1].last = x
+		} else {
+			r = append(r, interval{x, x})
+		}
+		return true
+	})
+	return r
+}
+
+// optimizeContainer returns the container in the representation that takes the least memory.
+func optimizeContainer(c container) container {
+	n, runs := c.len(), c.runs()
+	size := min(2*n, 8*bitmapWords)
+	switch runSize := 4 * runs; {
+	case runSize < size:
+		if r, ok := c.(runContainer); ok {
+			return r
+		}
+		return toRuns(c)
+	case n <= arrayMaxLen:
+		if a, ok := c.(arrayContainer); ok {
+			return a
+		}
+		return c.bitmap().array()
+	default:
+		if b, ok := c.(*bitmapContainer); ok {
+			return b
+		}
+		return c.bitmap()
+	}
+}
+
+// mergeArrays walks both arrays in order at the same time and builds a new container out of
+// the values that are only in a, in both arrays or only in b, as selected.
+func mergeArrays(a, b arrayContainer, onlyA, both, onlyB bool) container {
+	r := make(arrayContainer, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch x, y := a[i], b[j]; {
+		case x < y:
+			if onlyA {
+				r = append(r, x)
+			}
+			i++
+		case x > y:
+			if onlyB {
+				r = append(r, y)
+			}
+			j++
+		default:
+			if both {
+				r = append(r, x)
+			}
+			i, j = i+1, j+1
+		}
+	}
+	if onlyA {
+		r = append(r, a[i:]...)
+	}
+	if onlyB {
+		r = append(r, b[j:]...)
+	}
+	switch {
+	case len(r) == 0:
+		return nil
+	case len(r) > arrayMaxLen:
+		return r.bitmap()
+	default:
+		return slices.Clip(r)
+	}
+}
+
+// filterArray returns the values in a for which keep returns true.
+func filterArray(a arrayContainer, keep func(uint16) bool) container {
+	r := make(arrayContainer, 0, len(a))
+	for _, x := range a {
+		if keep(x) {
+			r = append(r, x)
+		}
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return slices.Clip(r)
+}
+
+// combineBitmaps applies op to all the words of both containers.
+func combineBitmaps(a, b container, op func(x, y uint64) uint64) container {
+	x, y := a.bitmap(), b.bitmap()
+	r := &bitmapContainer{}
+	for i := range r.words {
+		r.words[i] = op(x.words[i], y.words[i])
+		r.n += bits.OnesCount64(r.words[i])
+	}
+	return r.optimize()
+}
+
+func andContainers(a, b container) container {
+	aa, aok := a.(arrayContainer)
+	ba, bok := b.(arrayContainer)
+	switch {
+	case aok && bok:
+		return mergeArrays(aa, ba, false, true, false)
+	case aok:
+		return filterArray(aa, b.has)
+	case bok:
+		return filterArray(ba, a.has)
+	}
+	return combineBitmaps(a, b, func(x, y uint64) uint64 { return x & y })
+}
+
+func orContainers(a, b container) container {
+	aa, aok := a.(arrayContainer)
+	ba, bok := b.(arrayContainer)
+	if aok && bok {
+		return mergeArrays(aa, ba, true, true, true)
+	}
+	return combineBitmaps(a, b, func(x, y uint64) uint64 { return x | y })
+}
+
+func andNotContainers(a, b container) container {
+	aa, aok := a.(arrayContainer)
+	ba, bok := b.(arrayContainer)
+	switch {
+	case aok && bok:
+		return mergeArrays(aa, ba, true, false, false)
+	case aok:
+		return filterArray(aa, func(x uint16) bool { return !b.has(x) })
+	}
+	return combineBitmaps(a, b, func(x, y uint64) uint64 { return x &^ y })
+}
+
+func xorContainers(a, b container) container {
+	aa, aok := a.(arrayContainer)
+	ba, bok := b.(arrayContainer)
+	if aok && bok {
+		return mergeArrays(aa, ba, true, false, true)
+	}
+	return combineBitmaps(a, b, func(x, y uint64) uint64 { return x ^ y })
+}
@@ -0,0 +1,345 @@
+package set
+
+import (
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var r1 = NewRoaring(0, 1, 2, 3, 4)
+var r2 = NewRoaring(3, 4, 5, 6, 7)
+var r3 = NewRoaring(6, 7, 8, 9, 10)
+
+// checkRoaring verifies that the chunks are sorted, not empty and in the right representation.
+func checkRoaring(t *testing.T, r *Roaring) {
+	t.Helper()
+	assert.Equal(t, len(r.keys), len(r.containers))
+	assert.True(t, slices.IsSorted(r.keys))
+	for i, c := range r.containers {
+		assert.NotZero(t, c.len(), "chunk %d", r.keys[i])
+		switch c := c.(type) {
+		case arrayContainer:
+			assert.LessOrEqual(t, len(c), arrayMaxLen)
+			assert.True(t, slices.IsSorted(c))
+		case *bitmapContainer:
+			assert.Greater(t, c.n, arrayMaxLen)
+			n := 0
+			for _, w := range c.words {
+				n += bits.OnesCount64(w)
+			}
+			assert.Equal(t, n, c.n)
+		case runContainer:
+			for j := 1; j < len(c); j++ {
+				assert.Less(t, int(c[j-1].last)+1, int(c[j].start))
+			}
+		}
+	}
+}
+
+// randomRoaringKeys returns keys that are both sparse and dense in different chunks, with some long runs.
+func randomRoaringKeys(n int) []uint32 {
+	keys := make([]uint32, 0, n)
+	for len(keys) < n {
+		switch rand.IntN(3) {
+		case 0:
+			keys = append(keys, rand.Uint32())
+		case 1:
+			for range 20 {
+				keys = append(keys, 1<<16+rand.Uint32N(1<<14))
+			}
+		default:
+			start := 3<<16 + rand.Uint32N(1<<16-100)
+			for k := range uint32(rand.IntN(100)) {
+				keys = append(keys, start+k)
+			}
+		}
+	}
+	return keys
+}
+
+func TestNewRoaring(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected []uint32, r *Roaring) {
+		checkRoaring(t, r)
+		assert.Equal(t, expected, r.Elements())
+		assert.Equal(t, len(expected), r.Len())
+	}
+
+	var zero Roaring
+	t.Run("zero", func(t *testing.T) { check(t, []uint32{}, &zero) })
+	t.Run("none", func(t *testing.T) { check(t, []uint32{}, NewRoaring()) })
+	t.Run("{1<<20,2,1<<32-1,2,0}", func(t *testing.T) {
+		check(t, []uint32{0, 2, 1 << 20, 1<<32 - 1}, NewRoaring(1<<20, 2, 1<<32-1, 2, 0))
+	})
+	t.Run("copy", func(t *testing.T) { check(t, []uint32{0, 1, 2, 3, 4}, r1.Copy()) })
+}
+
+func TestRoaring_AddDel(t *testing.T) {
+	t.Parallel()
+
+	var r Roaring
+	r.Del(1)
+	r.Add(1<<16, 1, 1<<32-1, 1)
+	checkRoaring(t, &r)
+	assert.Equal(t, []uint32{1, 1 << 16, 1<<32 - 1}, r.Elements())
+	assert.True(t, r.Contains(1, 1<<16, 1<<32-1))
+	assert.False(t, r.Contains(1, 2))
+	assert.False(t, r.Contains(1<<16+1))
+
+	r.Del(1<<16, 5, 1<<20)
+	checkRoaring(t, &r)
+	assert.Equal(t, []uint32{1, 1<<32 - 1}, r.Elements())
+	r.Del(1, 1<<32-1)
+	checkRoaring(t, &r)
+	assert.Zero(t, r.Len())
+	assert.True(t, r.Equal(&Roaring{}))
+}
+
+func TestRoaring_Containers(t *testing.T) {
+	t.Parallel()
+
+	var r Roaring
+	for k := range uint32(arrayMaxLen) {
+		r.Add(2 * k)
+	}
+	assert.IsType(t, arrayContainer{}, r.containers[0])
+	r.Add(1)
+	checkRoaring(t, &r)
+	assert.IsType(t, &bitmapContainer{}, r.containers[0])
+	assert.Equal(t, arrayMaxLen+1, r.Len())
+	r.Del(1)
+	checkRoaring(t, &r)
+	assert.IsType(t, arrayContainer{}, r.containers[0])
+
+	// A full chunk is a single run.
+	var full Roaring
+	for k := range uint32(1 << 16) {
+		full.Add(5<<16 + k)
+	}
+	full.RunOptimize()
+	checkRoaring(t, &full)
+	assert.Equal(t, runContainer{{0, 1<<16 - 1}}, full.containers[0])
+	assert.Equal(t, 1<<16, full.Len())
+	assert.True(t, full.Contains(5<<16, 6<<16-1))
+	full.Del(5<<16+10, 5<<16)
+	full.Add(5<<16 + 11)
+	checkRoaring(t, &full)
+	assert.Equal(t, runContainer{{1, 9}, {11, 1<<16 - 1}}, full.containers[0])
+	full.Add(5<<16+10, 5<<16)
+	checkRoaring(t, &full)
+	assert.Equal(t, runContainer{{0, 1<<16 - 1}}, full.containers[0])
+	assert.Equal(t, []uint32{6<<16 - 2, 6<<16 - 1}, slices.Collect(full.Range(6<<16-2, 1<<32-1)))
+
+	// Sparse chunks stay arrays.
+	sparse := NewRoaring(1, 3, 5)
+	sparse.RunOptimize()
+	assert.IsType(t, arrayContainer{}, sparse.containers[0])
+}
+
+func TestRoaring_Random(t *testing.T) {
+	t.Parallel()
+
+	keys := randomRoaringKeys(20000)
+	model := New(keys...)
+	r := NewRoaring(keys...)
+	for range 500 {
+		k := keys[rand.IntN(len(keys))]
+		r.Del(k)
+		model.Del(k)
+	}
+	checkRoaring(t, r)
+	assert.Equal(t, model, r.ToSet())
+	assert.True(t, r.Equal(RoaringFromSet(model)))
+	sorted := model.Elements()
+	slices.Sort(sorted)
+	assert.Equal(t, sorted, r.Elements())
+
+	optimized := r.Copy()
+	optimized.RunOptimize()
+	checkRoaring(t, optimized)
+	assert.IsType(t, runContainer{}, optimized.containers[slices.Index(optimized.keys, 3)])
+	assert.True(t, r.Equal(optimized))
+	assert.True(t, optimized.Equal(r))
+	assert.Equal(t, sorted, optimized.Elements())
+	for range 1000 {
+		// Split, shrink, grow and join the runs.
+		k := 3<<16 + rand.Uint32N(1<<16)
+		if rand.IntN(2) == 0 {
+			optimized.Del(k)
+			model.Del(k)
+		} else {
+			optimized.Add(k)
+			model.Add(k)
+		}
+	}
+	checkRoaring(t, optimized)
+	assert.Equal(t, model, optimized.ToSet())
+}
+
+func TestRoaring_Range(t *testing.T) {
+	t.Parallel()
+
+	r := NewRoaring(1, 5, 1<<16, 1<<16+3, 1<<20, 1<<32-1)
+	check := func(t *testing.T, expected []uint32, lo, hi uint32) {
+		assert.Equal(t, expected, slices.Collect(r.Range(lo, hi)))
+	}
+	t.Run("all", func(t *testing.T) { check(t, []uint32{1, 5, 1 << 16, 1<<16 + 3, 1 << 20}, 0, 1<<32-1) })
+	t.Run("2,1<<16+3", func(t *testing.T) { check(t, []uint32{5, 1 << 16}, 2, 1<<16+3) })
+	t.Run("1<<16+1,1<<21", func(t *testing.T) { check(t, []uint32{1<<16 + 3, 1 << 20}, 1<<16+1, 1<<21) })
+	t.Run("empty", func(t *testing.T) { check(t, nil, 6, 1<<16) })
+	t.Run("reversed", func(t *testing.T) { check(t, nil, 1<<20, 0) })
+	t.Run("break", func(t *testing.T) {
+		for k := range r.Range(0, 1<<20) {
+			assert.Equal(t, uint32(1), k)
+			break
+		}
+	})
+	assert.Equal(t, []uint32{6, 7, 8, 9, 10}, slices.Collect(r3.All()))
+}
+
+func TestRoaring_Algebra(t *testing.T) {
+	t.Parallel()
+
+	null := &Roaring{}
+	check := func(t *testing.T, expected []uint32, r *Roaring) {
+		checkRoaring(t, r)
+		assert.Equal(t, expected, r.Elements())
+	}
+	t.Run("union", func(t *testing.T) {
+		check(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7}, r1.Union(r2))
+		check(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, r1.Union(r3, r2))
+		check(t, []uint32{0, 1, 2, 3, 4}, null.Union(r1))
+		check(t, []uint32{0, 1, 2, 3, 4}, r1.Union(r1))
+	})
+	t.Run("intersection", func(t *testing.T) {
+		check(t, []uint32{3, 4}, r1.Intersection(r2))
+		check(t, []uint32{}, r1.Intersection(r2, r3))
+		check(t, []uint32{}, null.Intersection(r1))
+		check(t, []uint32{0, 1, 2, 3, 4}, r1.Intersection(r1))
+	})
+	t.Run("difference", func(t *testing.T) {
+		check(t, []uint32{0, 1, 2}, r1.Difference(r2))
+		check(t, []uint32{5}, r2.Difference(r1, r3))
+		check(t, []uint32{0, 1, 2, 3, 4}, r1.Difference(null))
+		check(t, []uint32{}, r1.Difference(r1))
+	})
+	t.Run("symmetric difference", func(t *testing.T) {
+		check(t, []uint32{0, 1, 2, 5, 6, 7}, r1.SymmetricDifference(r2))
+		check(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, r1.SymmetricDifference(r2, r3))
+		check(t, []uint32{}, r1.SymmetricDifference(r1))
+	})
+
+	// Random sets mixing all kinds of containers.
+	keysA, keysB := randomRoaringKeys(30000), randomRoaringKeys(30000)
+	keysSparse := []uint32{1<<16 + 1, 1<<16 + 2, 1<<16 + 1000, 3<<16 + 5, 3<<16 + 1000, 3<<16 + 50000}
+	a, b := NewRoaring(keysA...), NewRoaring(keysB...)
+	optimized := b.Copy()
+	optimized.RunOptimize()
+	sa, sb := New(keysA...), New(keysB...)
+	pairs := []struct {
+		x, y   *Roaring
+		sx, sy Set[uint32]
+	}{
+		{a, b, sa, sb},
+		{a, optimized, sa, sb},
+		{optimized, a, sb, sa},
+		{NewRoaring(keysSparse...), optimized, New(keysSparse...), sb},
+		{optimized, NewRoaring(keysSparse...), sb, New(keysSparse...)},
+		{NewRoaring(keysSparse...), b, New(keysSparse...), sb},
+		{b, NewRoaring(keysSparse...), sb, New(keysSparse...)},
+	}
+	for _, p := range pairs {
+		r := p.x.Union(p.y)
+		checkRoaring(t, r)
+		assert.Equal(t, Union(p.sx, p.sy), r.ToSet())
+		r = p.x.Intersection(p.y)
+		checkRoaring(t, r)
+		assert.Equal(t, Intersection(p.sx, p.sy), r.ToSet())
+		r = p.x.Difference(p.y)
+		checkRoaring(t, r)
+		assert.Equal(t, Difference(p.sx, p.sy), r.ToSet())
+		r = p.x.SymmetricDifference(p.y)
+		checkRoaring(t, r)
+		assert.Equal(t, SymmetricDifference(p.sx, p.sy), r.ToSet())
+		assert.False(t, p.x.Equal(p.y))
+	}
+}
+
+func TestRoaring_Binary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("array", func(t *testing.T) {
+		data, err := NewRoaring(1, 2, 3).MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, []byte{
+			0x3a, 0x30, 0, 0, // cookie
+			1, 0, 0, 0, // number of chunks
+			0, 0, 2, 0, // key, length - 1
+			16, 0, 0, 0, // offset
+			1, 0, 2, 0, 3, 0,
+		}, data)
+	})
+	t.Run("runs", func(t *testing.T) {
+		var r Roaring
+		for k := range uint32(100) {
+			r.Add(1<<16 + 1 + k)
+		}
+		r.RunOptimize()
+		data, err := r.MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, []byte{
+			0x3b, 0x30, 0, 0, // cookie, number of chunks - 1
+			1,           // run flags
+			1, 0, 99, 0, // key, length - 1
+			1, 0, // number of runs
+			1, 0, 99, 0, // start, length - 1
+		}, data)
+	})
+
+	check := func(t *testing.T, r *Roaring) {
+		data, err := r.MarshalBinary()
+		require.NoError(t, err)
+		decoded := NewRoaring(1, 2, 3)
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		checkRoaring(t, decoded)
+		assert.True(t, r.Equal(decoded))
+		assert.Equal(t, r.Elements(), decoded.Elements())
+	}
+	t.Run("empty", func(t *testing.T) { check(t, &Roaring{}) })
+	t.Run("random", func(t *testing.T) { check(t, NewRoaring(randomRoaringKeys(30000)...)) })
+	t.Run("random optimized", func(t *testing.T) {
+		r := NewRoaring(randomRoaringKeys(30000)...)
+		r.RunOptimize()
+		check(t, r)
+	})
+}
+
+func TestRoaring_UnmarshalBinaryErrors(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected string, data []byte) {
+		var r Roaring
+		assert.EqualError(t, r.UnmarshalBinary(data), expected)
+	}
+	t.Run("empty", func(t *testing.T) { check(t, "set: roaring bitmap is truncated", nil) })
+	t.Run("cookie", func(t *testing.T) {
+		check(t, "set: invalid roaring bitmap cookie 0x1", []byte{1, 0, 0, 0})
+	})
+	t.Run("truncated", func(t *testing.T) {
+		check(t, "set: roaring bitmap is truncated", []byte{0x3a, 0x30, 0, 0, 1, 0, 0, 0, 0, 0, 2, 0, 16, 0, 0, 0, 1, 0})
+	})
+	t.Run("unsorted", func(t *testing.T) {
+		check(t, "set: roaring bitmap array is not sorted", []byte{0x3a, 0x30, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 16, 0, 0, 0, 2, 0, 1, 0})
+	})
+	t.Run("runs", func(t *testing.T) {
+		check(t, "set: roaring bitmap run is out of range", []byte{0x3b, 0x30, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0xff, 0xff, 1, 0})
+	})
+	t.Run("length", func(t *testing.T) {
+		check(t, "set: roaring bitmap chunk 0 has 100 keys instead of 1", []byte{0x3b, 0x30, 0, 0, 1, 0, 0, 0, 0, 1, 0, 1, 0, 99, 0})
+	})
+}
@@ -2,6 +2,7 @@
 package set
 
 import (
+	"iter"
 	"reflect"
 	"sort"
 )
@@ -115,6 +116,31 @@ func (s Set[Key]) Elements() []Key {
 	return keys
 }
 
+// All returns an iterator over all the keys in the set in no particular order.
+func (s Set[Key]) All() iter.Seq[Key] {
+	return func(yield func(Key) bool) {
+		for k := range s {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Collect creates a new set that contains all the keys yielded by seq.
+func Collect[Key comparable](seq iter.Seq[Key]) Set[Key] {
+	resultset := make(Set[Key])
+	resultset.AddSeq(seq)
+	return resultset
+}
+
+// AddSeq inserts all the keys yielded by seq into the set.
+func (s Set[Key]) AddSeq(seq iter.Seq[Key]) {
+	for k := range seq {
+		s[k] = struct{}{}
+	}
+}
+
 // Contains checks if the set contains all of the given keys.
 func (s Set[Key]) Contains(key Key, keys ...Key) bool {
 	// An empty set contains no keys.
@@ -356,6 +382,107 @@ func (s Set[Key]) SymmetricRemove(a Set[Key], sets ...Set[Key]) {
 	s.Remove(rm)
 }
 
+// Diff returns the keys that were added to and removed from oldset to get newset:
+// added = newset ∖ oldset and removed = oldset ∖ newset. Each set is walked only once.
+func Diff[Key comparable](oldset, newset Set[Key]) (added, removed Set[Key]) {
+	// Nothing changes between a set and itself.
+	if sameobject(oldset, newset) {
+		return make(Set[Key]), make(Set[Key])
+	}
+
+	added, removed = make(Set[Key]), make(Set[Key])
+	for k := range newset {
+		if !oldset.has(k) {
+			added[k] = struct{}{}
+		}
+	}
+	for k := range oldset {
+		if !newset.has(k) {
+			removed[k] = struct{}{}
+		}
+	}
+	return added, removed
+}
+
+// Pair is an ordered pair of keys, the element of a Cartesian product.
+type Pair[A, B comparable] struct {
+	First  A
+	Second B
+}
+
+// Product returns the Cartesian product of the sets: a × b = {(x, y) | x ∈ a, y ∈ b}.
+// The result has len(a) * len(b) keys.
+func Product[A, B comparable](a Set[A], b Set[B]) Set[Pair[A, B]] {
+	// The product with an empty set is empty.
+	if len(a) == 0 || len(b) == 0 {
+		return make(Set[Pair[A, B]])
+	}
+
+	resultset := make(Set[Pair[A, B]], len(a)*len(b))
+	for x := range a {
+		for y := range b {
+			resultset[Pair[A, B]{x, y}] = struct{}{}
+		}
+	}
+	return resultset
+}
+
+// PowerSet returns an iterator over all the subsets of s, from the smallest to the largest one,
+// starting with the empty set and ending with a copy of s. There are 2^len(s) subsets, which are
+// only created as they are iterated. The set must not be modified during the iteration.
+func PowerSet[Key comparable](s Set[Key]) iter.Seq[Set[Key]] {
+	return func(yield func(Set[Key]) bool) {
+		keys := s.Elements()
+		for k := 0; k <= len(keys); k++ {
+			if !combinations(keys, k, yield) {
+				return
+			}
+		}
+	}
+}
+
+// Combinations returns an iterator over all the subsets of s with k keys. There are
+// len(s)! / (k! * (len(s)-k)!) of them, which are only created as they are iterated.
+// The set must not be modified during the iteration.
+func Combinations[Key comparable](s Set[Key], k int) iter.Seq[Set[Key]] {
+	return func(yield func(Set[Key]) bool) {
+		if k < 0 || k > len(s) {
+			return
+		}
+		combinations(s.Elements(), k, yield)
+	}
+}
+
+// combinations yields a new set for every combination of k keys, in lexicographic order of
+// their indexes, until yield returns false.
+func combinations[Key comparable](keys []Key, k int, yield func(Set[Key]) bool) bool {
+	indexes := make([]int, k)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	for {
+		resultset := make(Set[Key], k)
+		for _, i := range indexes {
+			resultset[keys[i]] = struct{}{}
+		}
+		if !yield(resultset) {
+			return false
+		}
+		// Advance the rightmost index that can still move and reset the ones after it.
+		i := k - 1
+		for i >= 0 && indexes[i] == len(keys)-k+i {
+			i--
+		}
+		if i < 0 {
+			return true
+		}
+		indexes[i]++
+		for j := i + 1; j < k; j++ {
+			indexes[j] = indexes[j-1] + 1
+		}
+	}
+}
+
 // Does the same check as reflect.DeepEqual() for maps.
 // See: https://github.com/golang/go/blob/master/src/reflect/deepequal.go
 func sameobject[Key comparable](a, b Set[Key]) bool {
@@ -183,6 +183,20 @@ func BenchmarkElements(b *testing.B) {
 	}
 }
 
+func BenchmarkAll_Null(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for range nullSet.All() {
+		}
+	}
+}
+
+func BenchmarkAll(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for range randomSetX_1000.All() {
+		}
+	}
+}
+
 func BenchmarkContains_Null(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		nullSet.Contains(0, randomKeys_1000...)
@@ -1,6 +1,9 @@
 package set
 
 import (
+	"fmt"
+	"iter"
+	"slices"
 	"sort"
 	"testing"
 
@@ -112,6 +115,57 @@ func TestElements(t *testing.T) {
 	t.Run("s3", func(t *testing.T) { check(t, []E{6, 7, 8, 9, 10}, s3) })
 }
 
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected []E, s Set[E]) {
+		res := []E{}
+		for k := range s.All() {
+			res = append(res, k)
+		}
+		assert.ElementsMatch(t, expected, res)
+	}
+	t.Run("nil", func(t *testing.T) { check(t, []E{}, nil) })
+	t.Run("null", func(t *testing.T) { check(t, []E{}, null) })
+	t.Run("s1", func(t *testing.T) { check(t, []E{0, 1, 2, 3, 4}, s1) })
+	t.Run("break", func(t *testing.T) {
+		n := 0
+		for range s1.All() {
+			n++
+			break
+		}
+		assert.Equal(t, 1, n)
+	})
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected []E, s Set[E]) {
+		assert.Equal(t, expected, contents(Collect(s.All())))
+	}
+	t.Run("nil", func(t *testing.T) { check(t, []E{}, nil) })
+	t.Run("null", func(t *testing.T) { check(t, []E{}, null) })
+	t.Run("s1", func(t *testing.T) { check(t, []E{0, 1, 2, 3, 4}, s1) })
+	t.Run("slice", func(t *testing.T) {
+		assert.Equal(t, New[E](1, 2, 3), Collect(slices.Values([]E{3, 1, 2, 1})))
+	})
+}
+
+func TestAddSeq(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected Set[E], s Set[E], seq iter.Seq[E]) {
+		s = s.Copy()
+		s.AddSeq(seq)
+		assert.Equal(t, expected, s)
+	}
+	t.Run("null,null", func(t *testing.T) { check(t, null, null, null.All()) })
+	t.Run("null,s1", func(t *testing.T) { check(t, s1, null, s1.All()) })
+	t.Run("s1,s1", func(t *testing.T) { check(t, s1, s1, s1.All()) })
+	t.Run("s1,s2", func(t *testing.T) { check(t, New[E](0, 1, 2, 3, 4, 5, 6, 7), s1, s2.All()) })
+}
+
 func TestContains(t *testing.T) {
 	t.Parallel()
 
@@ -446,3 +500,100 @@ func TestSymmetricDifference(t *testing.T) {
 	t.Run("s3,s1,s2", func(t *testing.T) { check(t, symdiff_s1_s2_s3, s3, s1, s2) })
 	t.Run("s3,s2,s1", func(t *testing.T) { check(t, symdiff_s1_s2_s3, s3, s2, s1) })
 }
+
+func TestProduct(t *testing.T) {
+	t.Parallel()
+
+	type P = Pair[E, string]
+	strs := New("a", "b")
+	assert.Equal(t, New[P](), Product(snil, strs))
+	assert.Equal(t, New[P](), Product(s1, New[string]()))
+	assert.Equal(t, New(P{1, "a"}, P{1, "b"}, P{2, "a"}, P{2, "b"}), Product(New[E](1, 2), strs))
+	assert.Len(t, Product(s1, s1), 25)
+	assert.True(t, Product(s1, s1).Contains(Pair[E, E]{0, 4}, Pair[E, E]{4, 0}, Pair[E, E]{2, 2}))
+}
+
+func TestPowerSet(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, s Set[E]) {
+		var subsets []Set[E]
+		for subset := range PowerSet(s) {
+			subsets = append(subsets, subset)
+		}
+		assert.Len(t, subsets, 1<<len(s))
+		assert.Equal(t, null, subsets[0])
+		assert.True(t, Equal(s, subsets[len(subsets)-1]))
+		seen := New[string]()
+		for i, subset := range subsets {
+			ok, _ := subset.IsSubsetOf(s)
+			assert.True(t, ok)
+			if i > 0 {
+				assert.LessOrEqual(t, len(subsets[i-1]), len(subset))
+			}
+			seen.Add(fmt.Sprint(contents(subset)))
+		}
+		assert.Len(t, seen, len(subsets))
+	}
+
+	t.Run("nil", func(t *testing.T) { check(t, snil) })
+	t.Run("null", func(t *testing.T) { check(t, null) })
+	t.Run("s1", func(t *testing.T) { check(t, s1) })
+	t.Run("s2", func(t *testing.T) { check(t, s2) })
+	t.Run("lazy", func(t *testing.T) {
+		// Only the subsets that are iterated are created.
+		large := Collect(func(yield func(E) bool) {
+			for i := 0; i < 1000 && yield(i); i++ {
+			}
+		})
+		n := 0
+		for subset := range PowerSet(large) {
+			if n++; n == 1002 {
+				assert.Len(t, subset, 2)
+				break
+			}
+		}
+		assert.Equal(t, 1002, n)
+	})
+}
+
+func TestCombinations(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expected [][]E, s Set[E], k int) {
+		actual := [][]E{}
+		for subset := range Combinations(s, k) {
+			actual = append(actual, contents(subset))
+		}
+		slices.SortFunc(actual, slices.Compare)
+		assert.Equal(t, expected, actual)
+	}
+
+	t.Run("null,0", func(t *testing.T) { check(t, [][]E{{}}, null, 0) })
+	t.Run("null,1", func(t *testing.T) { check(t, [][]E{}, null, 1) })
+	t.Run("s1,-1", func(t *testing.T) { check(t, [][]E{}, s1, -1) })
+	t.Run("s1,0", func(t *testing.T) { check(t, [][]E{{}}, s1, 0) })
+	t.Run("s1,6", func(t *testing.T) { check(t, [][]E{}, s1, 6) })
+	t.Run("s1,5", func(t *testing.T) { check(t, [][]E{{0, 1, 2, 3, 4}}, s1, 5) })
+	t.Run("s1,1", func(t *testing.T) { check(t, [][]E{{0}, {1}, {2}, {3}, {4}}, s1, 1) })
+	t.Run("{1,2,3,4},2", func(t *testing.T) {
+		check(t, [][]E{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, New[E](1, 2, 3, 4), 2)
+	})
+	t.Run("s1,3", func(t *testing.T) {
+		n := 0
+		for subset := range Combinations(s1, 3) {
+			assert.Len(t, subset, 3)
+			n++
+		}
+		assert.Equal(t, 10, n)
+	})
+	t.Run("break", func(t *testing.T) {
+		n := 0
+		for range Combinations(s1, 2) {
+			if n++; n == 3 {
+				break
+			}
+		}
+		assert.Equal(t, 3, n)
+	})
+}
@@ -0,0 +1,205 @@
+package set
+
+import (
+	"math"
+	"slices"
+
+	"github.com/bitstonks/go-adt/internal/splitmix"
+)
+
+// IntersectionLen returns the number of keys in the intersection of all the sets,
+// without creating it: |⋂(a, b, sets)|.
+func IntersectionLen[Key comparable](a, b Set[Key], sets ...Set[Key]) int {
+	// The intersection of a set with itself is the set.
+	if len(sets) == 0 && sameobject(a, b) {
+		return len(a)
+	}
+
+	// Use the smallest set as the candidate keys.
+	candidate := a
+	if len(b) < len(candidate) {
+		candidate = b
+	}
+	for i := range sets {
+		if len(sets[i]) < len(candidate) {
+			candidate = sets[i]
+		}
+	}
+
+	// Any empty set in the arguments produces an empty intersection.
+	if len(candidate) == 0 {
+		return 0
+	}
+
+	n := 0
+outer:
+	for k := range candidate {
+		if !a.has(k) || !b.has(k) {
+			continue
+		}
+		for i := range sets {
+			if !sets[i].has(k) {
+				continue outer
+			}
+		}
+		n++
+	}
+	return n
+}
+
+// UnionLen returns the number of keys in the union of all the sets, without creating it:
+// |⋃(a, b, sets)|.
+func UnionLen[Key comparable](a, b Set[Key], sets ...Set[Key]) int {
+	// The union of a set with itself is the set.
+	if len(sets) == 0 && sameobject(a, b) {
+		return len(a)
+	}
+
+	// Count every key in the first set that contains it.
+	n := len(a)
+	for k := range b {
+		if !a.has(k) {
+			n++
+		}
+	}
+	for i := range sets {
+	outer:
+		for k := range sets[i] {
+			if a.has(k) || b.has(k) {
+				continue
+			}
+			for j := range i {
+				if sets[j].has(k) {
+					continue outer
+				}
+			}
+			n++
+		}
+	}
+	return n
+}
+
+// Jaccard returns the Jaccard index of the sets: |a ∩ b| / |a ∪ b|. It is 1 for equal sets,
+// including two empty sets, and 0 for disjoint sets.
+func Jaccard[Key comparable](a, b Set[Key]) float64 {
+	// The same set is always equal to itself.
+	if sameobject(a, b) || len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	n := IntersectionLen(a, b)
+	return float64(n) / float64(len(a)+len(b)-n)
+}
+
+// Dice returns the Sørensen–Dice coefficient of the sets: 2|a ∩ b| / (|a| + |b|). It is 1 for
+// equal sets, including two empty sets, and 0 for disjoint sets.
+func Dice[Key comparable](a, b Set[Key]) float64 {
+	// The same set is always equal to itself.
+	if sameobject(a, b) || len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	return 2 * float64(IntersectionLen(a, b)) / float64(len(a)+len(b))
+}
+
+// Overlap returns the overlap coefficient of the sets: |a ∩ b| / min(|a|, |b|). It is 1 when one
+// of the sets is a subset of the other and 0 for disjoint sets. It is 1 for two empty sets, but 0
+// for an empty and a non-empty set.
+func Overlap[Key comparable](a, b Set[Key]) float64 {
+	// The same set is always equal to itself.
+	if sameobject(a, b) || len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	// An empty set contains no keys.
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	return float64(IntersectionLen(a, b)) / float64(min(len(a), len(b)))
+}
+
+// MinHash is a sketch of a set that estimates the Jaccard index of large sets from a fixed
+// number of hashes, without comparing the keys. It keeps the minimum of every hash over the
+// keys, and the fraction of minimums two sketches share is the estimate. The standard error is
+// 1/√k for k hashes. The hashes are random for every process, so sketches can only be compared
+// within the same process.
+type MinHash[Key comparable] struct {
+	mins []uint64
+}
+
+// NewMinHash creates an empty sketch with k hashes. It panics if k is not positive.
+func NewMinHash[Key comparable](k int) *MinHash[Key] {
+	if k <= 0 {
+		panic("set: NewMinHash() called with k out of range")
+	}
+	mins := make([]uint64, k)
+	for i := range mins {
+		mins[i] = math.MaxUint64
+	}
+	return &MinHash[Key]{mins: mins}
+}
+
+// MinHashFromSet creates a sketch with k hashes of all the keys of the set.
+func MinHashFromSet[Key comparable](s Set[Key], k int) *MinHash[Key] {
+	m := NewMinHash[Key](k)
+	for key := range s {
+		m.add(key)
+	}
+	return m
+}
+
+// Hashes returns the number of hashes of the sketch.
+func (m *MinHash[Key]) Hashes() int {
+	return len(m.mins)
+}
+
+// Copy creates a deep copy of the sketch.
+func (m *MinHash[Key]) Copy() *MinHash[Key] {
+	return &MinHash[Key]{mins: slices.Clone(m.mins)}
+}
+
+// Add inserts keys into the sketch.
+func (m *MinHash[Key]) Add(key Key, keys ...Key) {
+	m.add(key)
+	for i := range keys {
+		m.add(keys[i])
+	}
+}
+
+// Update adds all the keys of the other sketches, so that the sketch is the same as the sketch
+// of the union of their sets. It panics if the sketches have different numbers of hashes.
+func (m *MinHash[Key]) Update(other *MinHash[Key], others ...*MinHash[Key]) {
+	for _, o := range append([]*MinHash[Key]{other}, others...) {
+		if len(o.mins) != len(m.mins) {
+			panic("set: Update() called with sketches of different sizes")
+		}
+		for i, h := range o.mins {
+			m.mins[i] = min(m.mins[i], h)
+		}
+	}
+}
+
+// Jaccard estimates the Jaccard index of the sets of the sketches. It panics if the sketches
+// have different numbers of hashes.
+func (m *MinHash[Key]) Jaccard(other *MinHash[Key]) float64 {
+	if len(other.mins) != len(m.mins) {
+		panic("set: Jaccard() called with sketches of different sizes")
+	}
+	same := 0
+	for i, h := range m.mins {
+		if other.mins[i] == h {
+			same++
+		}
+	}
+	return float64(same) / float64(len(m.mins))
+}
+
+// add updates the minimums with the hashes of key. The k hashes are derived from a single one
+// by double hashing, each of them mixed again so that they are independent enough.
+func (m *MinHash[Key]) add(key Key) {
+	h1 := hashKey(key)
+	h2 := splitmix.Mix(h1) | 1
+	for i := range m.mins {
+		m.mins[i] = min(m.mins[i], splitmix.Mix(h1+uint64(i)*h2))
+	}
+}
@@ -0,0 +1,99 @@
+package set
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntersectionUnionLen(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, a, b Set[E], sets ...Set[E]) {
+		assert.Equal(t, len(Intersection(a, b, sets...)), IntersectionLen(a, b, sets...))
+		assert.Equal(t, len(Union(a, b, sets...)), UnionLen(a, b, sets...))
+	}
+
+	all := map[string]Set[E]{"nil": snil, "null": null, "s1": s1, "s2": s2, "s3": s3, "subset3": subset3}
+	for an, a := range all {
+		for bn, b := range all {
+			t.Run(an+","+bn, func(t *testing.T) { check(t, a, b) })
+			for cn, c := range all {
+				t.Run(an+","+bn+","+cn, func(t *testing.T) { check(t, a, b, c) })
+			}
+		}
+	}
+	t.Run("s1,s2,s3,subset3", func(t *testing.T) { check(t, s1, s2, s3, subset3) })
+	t.Run("s3,subset3,s2,s3", func(t *testing.T) { check(t, s3, subset3, s2, s3) })
+}
+
+// Not parallel, AllocsPerRun doesn't work in parallel tests.
+func TestIntersectionUnionLen_Allocs(t *testing.T) {
+	assert.Zero(t, testing.AllocsPerRun(10, func() { IntersectionLen(s1, s2, s3) }))
+	assert.Zero(t, testing.AllocsPerRun(10, func() { UnionLen(s1, s2, s3) }))
+	assert.Zero(t, testing.AllocsPerRun(10, func() { Jaccard(s1, s2) }))
+}
+
+func TestSimilarity(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, jaccard, dice, overlap float64, a, b Set[E]) {
+		assert.InDelta(t, jaccard, Jaccard(a, b), 1e-9)
+		assert.InDelta(t, dice, Dice(a, b), 1e-9)
+		assert.InDelta(t, overlap, Overlap(a, b), 1e-9)
+		// All the metrics are symmetric.
+		assert.InDelta(t, jaccard, Jaccard(b, a), 1e-9)
+		assert.InDelta(t, dice, Dice(b, a), 1e-9)
+		assert.InDelta(t, overlap, Overlap(b, a), 1e-9)
+	}
+
+	t.Run("nil,null", func(t *testing.T) { check(t, 1, 1, 1, snil, null) })
+	t.Run("null,s1", func(t *testing.T) { check(t, 0, 0, 0, null, s1) })
+	t.Run("s1,s1", func(t *testing.T) { check(t, 1, 1, 1, s1, s1) })
+	t.Run("s1,copy", func(t *testing.T) { check(t, 1, 1, 1, s1, s1.Copy()) })
+	t.Run("s1,s2", func(t *testing.T) { check(t, 2.0/8, 4.0/10, 2.0/5, s1, s2) })
+	t.Run("s1,s3", func(t *testing.T) { check(t, 0, 0, 0, s1, s3) })
+	t.Run("s3,subset3", func(t *testing.T) { check(t, 2.0/5, 4.0/7, 1, s3, subset3) })
+}
+
+func TestMinHash(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "set: NewMinHash() called with k out of range", func() { NewMinHash[E](0) })
+	assert.Equal(t, 16, NewMinHash[E](16).Hashes())
+	assert.Equal(t, 1.0, NewMinHash[E](16).Jaccard(NewMinHash[E](16)))
+	assert.PanicsWithValue(t, "set: Jaccard() called with sketches of different sizes", func() {
+		NewMinHash[E](16).Jaccard(NewMinHash[E](8))
+	})
+	assert.PanicsWithValue(t, "set: Update() called with sketches of different sizes", func() {
+		NewMinHash[E](16).Update(NewMinHash[E](8))
+	})
+
+	// Sketches of the same keys are the same, no matter how the keys were added.
+	m := NewMinHash[E](64)
+	m.Add(0, 1, 2)
+	m.Add(3, 4, 0)
+	assert.Equal(t, MinHashFromSet(s1, 64), m)
+	assert.Equal(t, 1.0, m.Jaccard(MinHashFromSet(s1, 64)))
+	u := MinHashFromSet(s1, 64)
+	u.Update(MinHashFromSet(s2, 64), MinHashFromSet(s3, 64))
+	assert.Equal(t, MinHashFromSet(Union(s1, s2, s3), 64), u)
+	c := u.Copy()
+	c.Add(100)
+	assert.NotEqual(t, u, c)
+
+	// The estimates are within a few standard errors of the exact Jaccard index.
+	a, b := New[string](), New[string]()
+	for i := range 3000 {
+		a.Add(fmt.Sprint(i))
+	}
+	for i := 1000; i < 5000; i++ {
+		b.Add(fmt.Sprint(i))
+	}
+	for _, k := range []int{64, 256, 1024} {
+		estimate := MinHashFromSet(a, k).Jaccard(MinHashFromSet(b, k))
+		assert.InDelta(t, Jaccard(a, b), estimate, 2/math.Sqrt(float64(k)), "k=%d", k)
+	}
+}
@@ -0,0 +1,179 @@
+package set
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Sync is a wrapper around Set ensuring that all operations are properly synchronised
+// using an internal mutex. Operations on several sets lock all of them at once, always
+// in the same order, so they are consistent and can't deadlock. The zero value is an empty
+// set ready to use.
+type Sync[K comparable] struct {
+	nosync Set[K]
+	lock   sync.RWMutex
+}
+
+// NewSync creates a new synchronised set that contains all the given keys.
+func NewSync[K comparable](keys ...K) *Sync[K] {
+	return &Sync[K]{nosync: New(keys...)}
+}
+
+// Len returns the number of keys in the set.
+func (s *Sync[K]) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.nosync)
+}
+
+// Snapshot returns a copy of the set as it is now.
+func (s *Sync[K]) Snapshot() Set[K] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.nosync.Copy()
+}
+
+// Contains checks if the set contains all of the given keys.
+func (s *Sync[K]) Contains(key K, keys ...K) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.nosync.Contains(key, keys...)
+}
+
+// Add inserts keys into the set.
+func (s *Sync[K]) Add(key K, keys ...K) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.init()
+	s.nosync.Add(key, keys...)
+}
+
+// Del removes keys from the set.
+func (s *Sync[K]) Del(key K, keys ...K) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.nosync.Del(key, keys...)
+}
+
+// AddIfAbsent inserts key into the set and reports whether it wasn't in the set before.
+func (s *Sync[K]) AddIfAbsent(key K) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.nosync.has(key) {
+		return false
+	}
+	s.init()
+	s.nosync[key] = struct{}{}
+	return true
+}
+
+// DelIfPresent removes key from the set and reports whether it was in the set before.
+func (s *Sync[K]) DelIfPresent(key K) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.nosync.has(key) {
+		return false
+	}
+	delete(s.nosync, key)
+	return true
+}
+
+// Union returns the union of all the sets as a new Set: ⋃(s, a, sets).
+func (s *Sync[K]) Union(a *Sync[K], sets ...*Sync[K]) Set[K] {
+	defer lockSyncs(nil, sets, s, a)()
+	return Union(s.nosync, a.nosync, unwrapSyncs(sets)...)
+}
+
+// Intersection returns the intersection of all the sets as a new Set: ⋂(s, a, sets).
+func (s *Sync[K]) Intersection(a *Sync[K], sets ...*Sync[K]) Set[K] {
+	defer lockSyncs(nil, sets, s, a)()
+	return Intersection(s.nosync, a.nosync, unwrapSyncs(sets)...)
+}
+
+// Difference returns the difference of all the sets as a new Set: s ∖ a ∖ sets[0] ∖ sets[1] ...
+func (s *Sync[K]) Difference(a *Sync[K], sets ...*Sync[K]) Set[K] {
+	defer lockSyncs(nil, sets, s, a)()
+	return Difference(s.nosync, a.nosync, unwrapSyncs(sets)...)
+}
+
+// SymmetricDifference returns the difference between the union and intersection of all the sets
+// as a new Set: ⋃(s, a, sets) ∖ ⋂(s, a, sets).
+func (s *Sync[K]) SymmetricDifference(a *Sync[K], sets ...*Sync[K]) Set[K] {
+	defer lockSyncs(nil, sets, s, a)()
+	return SymmetricDifference(s.nosync, a.nosync, unwrapSyncs(sets)...)
+}
+
+// Update is like Union, but modifies the set in place.
+func (s *Sync[K]) Update(a *Sync[K], sets ...*Sync[K]) {
+	defer lockSyncs(s, sets, a)()
+	s.init()
+	s.nosync.Update(a.nosync, unwrapSyncs(sets)...)
+}
+
+// Intersect is like Intersection, but modifies the set in place.
+func (s *Sync[K]) Intersect(a *Sync[K], sets ...*Sync[K]) {
+	defer lockSyncs(s, sets, a)()
+	s.nosync.Intersect(a.nosync, unwrapSyncs(sets)...)
+}
+
+// Remove is like Difference, but modifies the set in place.
+func (s *Sync[K]) Remove(a *Sync[K], sets ...*Sync[K]) {
+	defer lockSyncs(s, sets, a)()
+	s.nosync.Remove(a.nosync, unwrapSyncs(sets)...)
+}
+
+// SymmetricRemove is like SymmetricDifference, but modifies the set in place.
+func (s *Sync[K]) SymmetricRemove(a *Sync[K], sets ...*Sync[K]) {
+	defer lockSyncs(s, sets, a)()
+	s.init()
+	s.nosync.SymmetricRemove(a.nosync, unwrapSyncs(sets)...)
+}
+
+// init allocates the set of a zero value Sync. Must be called with the write lock held.
+func (s *Sync[K]) init() {
+	if s.nosync == nil {
+		s.nosync = make(Set[K])
+	}
+}
+
+// lockSyncs locks w (if not nil) for writing and all the sets in sets and others for reading.
+// Every set is locked only once and always in the order of their addresses, so that concurrent
+// operations on overlapping sets can't deadlock. It returns a function that unlocks all of them.
+func lockSyncs[K comparable](w *Sync[K], sets []*Sync[K], others ...*Sync[K]) (unlock func()) {
+	// Do *not* modify the function argument array, copy it before sorting.
+	syncs := make([]*Sync[K], 0, 1+len(sets)+len(others))
+	if w != nil {
+		syncs = append(syncs, w)
+	}
+	syncs = append(append(syncs, sets...), others...)
+	slices.SortFunc(syncs, func(a, b *Sync[K]) int {
+		return cmp.Compare(reflect.ValueOf(a).Pointer(), reflect.ValueOf(b).Pointer())
+	})
+	syncs = slices.Compact(syncs)
+	for _, s := range syncs {
+		if s == w {
+			s.lock.Lock()
+		} else {
+			s.lock.RLock()
+		}
+	}
+	return func() {
+		for _, s := range slices.Backward(syncs) {
+			if s == w {
+				s.lock.Unlock()
+			} else {
+				s.lock.RUnlock()
+			}
+		}
+	}
+}
+
+func unwrapSyncs[K comparable](syncs []*Sync[K]) []Set[K] {
+	sets := make([]Set[K], len(syncs))
+	for i := range syncs {
+		sets[i] = syncs[i].nosync
+	}
+	return sets
+}
@@ -0,0 +1,149 @@
+package set
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSync_AddDel(t *testing.T) {
+	t.Parallel()
+
+	var s Sync[E]
+	assert.False(t, s.Contains(1))
+	s.Del(1)
+	s.Add(1, 2, 3)
+	assert.Equal(t, 3, s.Len())
+	assert.True(t, s.Contains(1, 2, 3))
+	s.Del(1, 2)
+	assert.Equal(t, New[E](3), s.Snapshot())
+}
+
+func TestSync_IfAbsentIfPresent(t *testing.T) {
+	t.Parallel()
+
+	var s Sync[E]
+	assert.False(t, s.DelIfPresent(1))
+	assert.True(t, s.AddIfAbsent(1))
+	assert.False(t, s.AddIfAbsent(1))
+	assert.True(t, s.DelIfPresent(1))
+	assert.False(t, s.DelIfPresent(1))
+	assert.Zero(t, s.Len())
+}
+
+func TestSync_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	s := NewSync[E](0, 1, 2, 3, 4)
+	snapshot := s.Snapshot()
+	s.Add(5)
+	assert.Equal(t, s1, snapshot)
+	assert.Equal(t, New[E](), (&Sync[E]{}).Snapshot())
+}
+
+func TestSync_Algebra(t *testing.T) {
+	t.Parallel()
+
+	y1, y2, y3 := NewSync[E](s1.Elements()...), NewSync[E](s2.Elements()...), NewSync[E](s3.Elements()...)
+	assert.Equal(t, Union(s1, s2, s3), y1.Union(y2, y3))
+	assert.Equal(t, Intersection(s1, s2), y1.Intersection(y2))
+	assert.Equal(t, Difference(s2, s1, s3), y2.Difference(y1, y3))
+	assert.Equal(t, SymmetricDifference(s1, s2), y1.SymmetricDifference(y2))
+	assert.Equal(t, s1, y1.Union(y1))
+
+	check := func(t *testing.T, expected Set[E], op func(s *Sync[E])) {
+		s := NewSync(s1.Elements()...)
+		op(s)
+		assert.Equal(t, expected, s.Snapshot())
+	}
+	t.Run("update", func(t *testing.T) {
+		check(t, Union(s1, s2, s3), func(s *Sync[E]) { s.Update(y2, y3) })
+		check(t, s1, func(s *Sync[E]) { s.Update(s) })
+	})
+	t.Run("intersect", func(t *testing.T) {
+		check(t, Intersection(s1, s2), func(s *Sync[E]) { s.Intersect(y2) })
+		check(t, s1, func(s *Sync[E]) { s.Intersect(s, s) })
+	})
+	t.Run("remove", func(t *testing.T) {
+		check(t, Difference(s1, s2), func(s *Sync[E]) { s.Remove(y2) })
+		check(t, null, func(s *Sync[E]) { s.Remove(s) })
+	})
+	t.Run("symmetric remove", func(t *testing.T) {
+		check(t, SymmetricDifference(s1, s2, s3), func(s *Sync[E]) { s.SymmetricRemove(y2, y3) })
+	})
+	t.Run("zero", func(t *testing.T) {
+		var s Sync[E]
+		s.Update(y1)
+		assert.Equal(t, s1, s.Snapshot())
+	})
+}
+
+func TestSync_AddIfAbsentConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var s Sync[E]
+	var wg sync.WaitGroup
+	n, workers := 1000, 4
+	wins := make([]int, workers)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range n {
+				if s.AddIfAbsent(i) {
+					wins[w]++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	total := 0
+	for _, w := range wins {
+		total += w
+	}
+	// Every key is added exactly once.
+	assert.Equal(t, n, total)
+	assert.Equal(t, n, s.Len())
+}
+
+func TestSync_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewSync[E](), NewSync[E]()
+	var wg sync.WaitGroup
+	n := 1000
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		for i := range n {
+			a.Add(i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := range n {
+			b.Add(n + i)
+		}
+	}()
+	// Operations locking the same sets in opposite argument order must not deadlock.
+	go func() {
+		defer wg.Done()
+		for range n {
+			a.Update(b)
+			a.Union(b)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range n {
+			b.Update(a)
+			b.Intersection(a)
+		}
+	}()
+	wg.Wait()
+	a.Update(b)
+	b.Update(a)
+	assert.Equal(t, 2*n, a.Len())
+	assert.True(t, Equal(a.Snapshot(), b.Snapshot()))
+}
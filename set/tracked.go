@@ -0,0 +1,183 @@
+package set
+
+// Tracked is a wrapper around Set that records every change in a journal, so that changes can
+// be undone and the set can be rolled back to an earlier checkpoint. A change is everything done
+// by a single call, and only keys that were actually added or removed are recorded. The journal
+// keeps growing with every change. The zero value is an empty set ready to use. Tracked sets
+// must not be copied by value and have to be synchronised externally.
+type Tracked[K comparable] struct {
+	set     Set[K]
+	journal []trackedChange[K]
+	// lastID is the id of the last change ever recorded, including the undone ones.
+	lastID   uint64
+	onChange func(added, removed Set[K])
+}
+
+// trackedChange is an entry of the journal.
+type trackedChange[K comparable] struct {
+	id             uint64
+	added, removed Set[K]
+}
+
+// Checkpoint identifies a state of a tracked set, see Tracked.Rollback().
+type Checkpoint struct {
+	id uint64
+}
+
+// NewTracked creates a new tracked set that contains all the given keys. Adding them is not
+// recorded in the journal.
+func NewTracked[K comparable](keys ...K) *Tracked[K] {
+	return &Tracked[K]{set: New(keys...)}
+}
+
+// Len returns the number of keys in the set.
+func (t *Tracked[K]) Len() int {
+	return len(t.set)
+}
+
+// Snapshot returns a copy of the set as it is now.
+func (t *Tracked[K]) Snapshot() Set[K] {
+	return t.set.Copy()
+}
+
+// Contains checks if the set contains all of the given keys.
+func (t *Tracked[K]) Contains(key K, keys ...K) bool {
+	return t.set.Contains(key, keys...)
+}
+
+// OnChange sets a callback that is called after every change, including the changes made by Undo
+// and Rollback, with the keys that were added and removed. The sets passed to the callback must
+// not be modified. A nil callback removes the previous one.
+func (t *Tracked[K]) OnChange(fn func(added, removed Set[K])) {
+	t.onChange = fn
+}
+
+// Add inserts keys into the set.
+func (t *Tracked[K]) Add(key K, keys ...K) {
+	added := make(Set[K])
+	t.add(added, key)
+	for i := range keys {
+		t.add(added, keys[i])
+	}
+	t.record(added, make(Set[K]))
+}
+
+// Del removes keys from the set.
+func (t *Tracked[K]) Del(key K, keys ...K) {
+	removed := make(Set[K])
+	t.del(removed, key)
+	for i := range keys {
+		t.del(removed, keys[i])
+	}
+	t.record(make(Set[K]), removed)
+}
+
+// Update inserts all the keys of the given sets into the set, like Set.Update.
+func (t *Tracked[K]) Update(a Set[K], sets ...Set[K]) {
+	added := make(Set[K])
+	for k := range a {
+		t.add(added, k)
+	}
+	for i := range sets {
+		for k := range sets[i] {
+			t.add(added, k)
+		}
+	}
+	t.record(added, make(Set[K]))
+}
+
+// Remove removes all the keys of the given sets from the set, like Set.Remove.
+func (t *Tracked[K]) Remove(a Set[K], sets ...Set[K]) {
+	removed := make(Set[K])
+	sets = append(sets, a)
+	for k := range t.set {
+		for i := range sets {
+			if sets[i].has(k) {
+				removed[k] = struct{}{}
+				break
+			}
+		}
+	}
+	for k := range removed {
+		delete(t.set, k)
+	}
+	t.record(make(Set[K]), removed)
+}
+
+// Undo reverts the last change that wasn't undone yet and reports whether there was one.
+func (t *Tracked[K]) Undo() bool {
+	if len(t.journal) == 0 {
+		return false
+	}
+	c := t.journal[len(t.journal)-1]
+	t.journal = t.journal[:len(t.journal)-1]
+	t.set.Remove(c.added)
+	t.set.Update(c.removed)
+	t.notify(c.removed, c.added)
+	return true
+}
+
+// Checkpoint returns the current state of the set, which it can be rolled back to later.
+func (t *Tracked[K]) Checkpoint() Checkpoint {
+	if len(t.journal) == 0 {
+		return Checkpoint{}
+	}
+	return Checkpoint{id: t.journal[len(t.journal)-1].id}
+}
+
+// Rollback undoes all the changes made since the checkpoint. It panics if the checkpoint
+// itself was undone in the meantime.
+func (t *Tracked[K]) Rollback(cp Checkpoint) {
+	if cp.id != 0 && !t.recorded(cp.id) {
+		panic("set: Rollback() called with a checkpoint that was undone")
+	}
+	for len(t.journal) > 0 && t.journal[len(t.journal)-1].id > cp.id {
+		t.Undo()
+	}
+}
+
+func (t *Tracked[K]) add(added Set[K], key K) {
+	if t.set.has(key) {
+		return
+	}
+	if t.set == nil {
+		t.set = make(Set[K])
+	}
+	t.set[key] = struct{}{}
+	added[key] = struct{}{}
+}
+
+func (t *Tracked[K]) del(removed Set[K], key K) {
+	if !t.set.has(key) {
+		return
+	}
+	delete(t.set, key)
+	removed[key] = struct{}{}
+}
+
+// record appends a change to the journal, unless nothing changed.
+func (t *Tracked[K]) record(added, removed Set[K]) {
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	t.lastID++
+	t.journal = append(t.journal, trackedChange[K]{id: t.lastID, added: added, removed: removed})
+	t.notify(added, removed)
+}
+
+func (t *Tracked[K]) notify(added, removed Set[K]) {
+	if t.onChange != nil {
+		t.onChange(added, removed)
+	}
+}
+
+// recorded checks if the change with the given id is still in the journal.
+func (t *Tracked[K]) recorded(id uint64) bool {
+	// The ids in the journal are increasing.
+	for i := len(t.journal) - 1; i >= 0 && t.journal[i].id >= id; i-- {
+		if t.journal[i].id == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, expectedAdded, expectedRemoved Set[E], oldset, newset Set[E]) {
+		added, removed := Diff(oldset, newset)
+		assert.Equal(t, expectedAdded, added)
+		assert.Equal(t, expectedRemoved, removed)
+	}
+
+	t.Run("nil,nil", func(t *testing.T) { check(t, null, null, snil, snil) })
+	t.Run("null,s1", func(t *testing.T) { check(t, s1, null, null, s1) })
+	t.Run("s1,null", func(t *testing.T) { check(t, null, s1, s1, null) })
+	t.Run("s1,s1", func(t *testing.T) { check(t, null, null, s1, s1) })
+	t.Run("s1,copy", func(t *testing.T) { check(t, null, null, s1, s1.Copy()) })
+	t.Run("s1,s2", func(t *testing.T) { check(t, New[E](5, 6, 7), New[E](0, 1, 2), s1, s2) })
+	t.Run("s3,subset3", func(t *testing.T) { check(t, null, New[E](8, 9, 10), s3, subset3) })
+}
+
+func TestTracked(t *testing.T) {
+	t.Parallel()
+
+	var s Tracked[E]
+	assert.False(t, s.Undo())
+	assert.False(t, s.Contains(1))
+	s.Add(1, 2, 3)
+	s.Del(3, 4)
+	s.Update(New[E](3, 4), New[E](1, 5))
+	s.Remove(New[E](1), New[E](2, 6))
+	assert.Equal(t, New[E](3, 4, 5), s.Snapshot())
+	assert.Equal(t, 3, s.Len())
+	assert.True(t, s.Contains(3, 4, 5))
+
+	// Changes that don't change anything are not recorded.
+	s.Add(3)
+	s.Del(7)
+	s.Update(New[E](4))
+	s.Remove(New[E](8))
+
+	assert.True(t, s.Undo())
+	assert.Equal(t, New[E](1, 2, 3, 4, 5), s.Snapshot())
+	assert.True(t, s.Undo())
+	assert.Equal(t, New[E](1, 2), s.Snapshot())
+	assert.True(t, s.Undo())
+	assert.Equal(t, New[E](1, 2, 3), s.Snapshot())
+	assert.True(t, s.Undo())
+	assert.Equal(t, null, s.Snapshot())
+	assert.False(t, s.Undo())
+
+	s2 := NewTracked[E](1, 2)
+	assert.False(t, s2.Undo())
+	assert.Equal(t, New[E](1, 2), s2.Snapshot())
+}
+
+func TestTracked_Rollback(t *testing.T) {
+	t.Parallel()
+
+	s := NewTracked[E](1)
+	start := s.Checkpoint()
+	s.Add(2)
+	cp := s.Checkpoint()
+	s.Add(3)
+	s.Del(1)
+	assert.NotEqual(t, cp, s.Checkpoint())
+
+	s.Rollback(cp)
+	assert.Equal(t, New[E](1, 2), s.Snapshot())
+	// Rolling back to the current state does nothing.
+	s.Rollback(cp)
+	assert.Equal(t, New[E](1, 2), s.Snapshot())
+
+	s.Add(4)
+	s.Rollback(start)
+	assert.Equal(t, New[E](1), s.Snapshot())
+
+	// A checkpoint that was undone can't be rolled back to, even if there were more changes since.
+	s.Add(5)
+	assert.PanicsWithValue(t, "set: Rollback() called with a checkpoint that was undone", func() { s.Rollback(cp) })
+	assert.Equal(t, New[E](1, 5), s.Snapshot())
+}
+
+func TestTracked_OnChange(t *testing.T) {
+	t.Parallel()
+
+	type change struct{ added, removed Set[E] }
+	var changes []change
+	s := NewTracked[E](1)
+	s.OnChange(func(added, removed Set[E]) {
+		changes = append(changes, change{added.Copy(), removed.Copy()})
+	})
+
+	cp := s.Checkpoint()
+	s.Add(1, 2, 3)
+	s.Del(1, 4)
+	s.Add(3)
+	s.Undo()
+	s.Update(New[E](4))
+	s.Rollback(cp)
+	s.OnChange(nil)
+	s.Add(5)
+
+	assert.Equal(t, []change{
+		{New[E](2, 3), null},
+		{null, New[E](1)},
+		{New[E](1), null},
+		{New[E](4), null},
+		{null, New[E](4)},
+		{null, New[E](2, 3)},
+	}, changes)
+}